@@ -0,0 +1,54 @@
+/*
+Hebcal-server is a local HTTP REST server for Jewish calendar data,
+with query parameters modelled on the hebcal.com REST API.
+
+Endpoints:
+
+	/hebcal     holidays, Torah readings, candle-lighting and more
+	            (year, month, start, end, maj, min, nx, mf, ss, mod, s,
+	            c, geo, city, latitude, longitude, tzid, b, m, i, lg, ...)
+	/converter  Gregorian to Hebrew (gy, gm, gd or date) and
+	            Hebrew to Gregorian (h2g=1, hy, hm, hd) date conversion
+	/zmanim     halachic times for a location and date
+	/shabbat    candle-lighting, parsha and Havdalah for the coming Shabbat
+
+/hebcal and /shabbat return JSON, iCalendar or CSV depending on the cfg
+parameter (json, ics or csv) or the Accept header. Every response
+carries an ETag derived from its query parameters, so calendar clients
+can revalidate subscriptions with If-None-Match.
+
+Usage:
+
+	hebcal-server [-addr :8080]
+*/
+package main
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+	log.Printf("hebcal-server listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, newServer(time.Now)))
+}
@@ -0,0 +1,43 @@
+package main
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hebcal/hdate"
+)
+
+func isOn(q url.Values, key string) bool {
+	v := q.Get(key)
+	return v == "on" || v == "1" || v == "true"
+}
+
+// parseDate parses a YYYY-MM-DD date parameter.
+func parseDate(q url.Values, key string) (hdate.HDate, bool, error) {
+	v := q.Get(key)
+	if v == "" {
+		return hdate.HDate{}, false, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return hdate.HDate{}, false, fmt.Errorf("invalid %s=%q", key, v)
+	}
+	return hdate.FromTime(t), true, nil
+}
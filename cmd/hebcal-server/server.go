@@ -0,0 +1,406 @@
+package main
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+)
+
+const (
+	formatJSON = "json"
+	formatICal = "ics"
+	formatCSV  = "csv"
+)
+
+// Responses that depend only on explicit dates never change, so
+// clients may cache them for a week. Responses relative to "today"
+// are cached for an hour.
+const (
+	cacheStable   = "public, max-age=604800"
+	cacheRelative = "public, max-age=3600"
+)
+
+type server struct {
+	now func() time.Time
+}
+
+// newServer returns an http.Handler serving /hebcal, /converter,
+// /zmanim and /shabbat.
+func newServer(now func() time.Time) http.Handler {
+	s := &server{now: now}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hebcal", s.handleHebcal)
+	mux.HandleFunc("/converter", s.handleConverter)
+	mux.HandleFunc("/zmanim", s.handleZmanim)
+	mux.HandleFunc("/shabbat", s.handleShabbat)
+	return mux
+}
+
+// negotiateFormat picks the output format from the cfg parameter,
+// falling back to the Accept header, then JSON.
+func negotiateFormat(r *http.Request) (string, error) {
+	switch cfg := r.URL.Query().Get("cfg"); cfg {
+	case "json", "ics", "csv":
+		return cfg, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unsupported cfg=%q", cfg)
+	}
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/calendar"):
+		return formatICal, nil
+	case strings.Contains(accept, "text/csv"):
+		return formatCSV, nil
+	}
+	return formatJSON, nil
+}
+
+// makeETag hashes the canonical form of the query (sorted keys, minus
+// parameters that don't affect output) together with the format.
+// dateKey is non-empty for responses relative to the current date.
+func makeETag(path string, q url.Values, format, dateKey string) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		if k != "cfg" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", path, format, dateKey)
+	for _, k := range keys {
+		values := append([]string(nil), q[k]...)
+		sort.Strings(values)
+		fmt.Fprintf(h, "\x00%s=%s", k, strings.Join(values, ","))
+	}
+	return fmt.Sprintf(`W/"%016x"`, h.Sum64())
+}
+
+// checkCache sets ETag and Cache-Control headers and returns true
+// (after writing 304 Not Modified) if the client already has this
+// representation.
+func checkCache(w http.ResponseWriter, r *http.Request, etag string, relative bool) bool {
+	w.Header().Set("ETag", etag)
+	if relative {
+		w.Header().Set("Cache-Control", cacheRelative)
+	} else {
+		w.Header().Set("Cache-Control", cacheStable)
+	}
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// writeEvents renders a calendar in the negotiated format.
+func writeEvents(w http.ResponseWriter, events []event.CalEvent, opts *hebcal.CalOptions, locale, format string) {
+	var buf bytes.Buffer
+	var err error
+	var contentType string
+	switch format {
+	case formatICal:
+		contentType = "text/calendar; charset=utf-8"
+		err = hebcal.WriteICalendar(&buf, events, opts, hebcal.ICalOptions{Locale: locale})
+	case formatCSV:
+		contentType = "text/csv; charset=utf-8"
+		err = hebcal.WriteCSV(&buf, events, opts, locale)
+	default:
+		contentType = "application/json; charset=utf-8"
+		err = hebcal.WriteJSON(&buf, events, opts, locale)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+}
+
+func isRelative(q url.Values) bool {
+	year := q.Get("year")
+	return (year == "" || year == "now") && q.Get("start") == ""
+}
+
+func (s *server) handleHebcal(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format, err := negotiateFormat(r)
+	if err != nil {
+		writeError(w, http.StatusNotAcceptable, err)
+		return
+	}
+	now := s.now()
+	opts, err := hebcal.ParseQuery(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	relative := isRelative(q)
+	dateKey := ""
+	if relative {
+		dateKey = now.Format("2006-01-02")
+	}
+	if checkCache(w, r, makeETag(r.URL.Path, q, format, dateKey), relative) {
+		return
+	}
+	events, err := hebcal.HebrewCalendar(opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeEvents(w, events, opts, opts.Locale, format)
+}
+
+type converterResponse struct {
+	Gy     int      `json:"gy"`
+	Gm     int      `json:"gm"`
+	Gd     int      `json:"gd"`
+	Hy     int      `json:"hy"`
+	Hm     string   `json:"hm"`
+	Hd     int      `json:"hd"`
+	Hebrew string   `json:"hebrew"`
+	Events []string `json:"events"`
+}
+
+// handleConverter converts Gregorian to Hebrew (gy, gm, gd or date)
+// or Hebrew to Gregorian (hy, hm, hd with h2g=1).
+func (s *server) handleConverter(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts, err := hebcal.ParseQuery(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var hd hdate.HDate
+	relative := false
+	if q.Get("h2g") != "" {
+		hy, err1 := strconv.Atoi(q.Get("hy"))
+		hd0, err2 := strconv.Atoi(q.Get("hd"))
+		hm, err3 := hdate.MonthFromName(q.Get("hm"))
+		if err1 != nil || err2 != nil || err3 != nil || hy < 1 ||
+			hd0 < 1 || hd0 > hdate.DaysInMonth(hm, hy) {
+			writeError(w, http.StatusBadRequest, errors.New("h2g requires valid hy, hm and hd"))
+			return
+		}
+		hd = hdate.New(hy, hm, hd0)
+	} else if q.Get("date") != "" {
+		hd, _, err = parseDate(q, "date")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	} else if q.Get("gy") != "" {
+		gy, err1 := strconv.Atoi(q.Get("gy"))
+		gm, err2 := strconv.Atoi(q.Get("gm"))
+		gd, err3 := strconv.Atoi(q.Get("gd"))
+		t := time.Date(gy, time.Month(gm), gd, 0, 0, 0, 0, time.UTC)
+		if err1 != nil || err2 != nil || err3 != nil || gy < 1 ||
+			t.Year() != gy || int(t.Month()) != gm || t.Day() != gd {
+			writeError(w, http.StatusBadRequest, errors.New("g2h requires valid gy, gm and gd"))
+			return
+		}
+		hd = hdate.FromTime(t)
+	} else {
+		relative = true
+		hd = hdate.FromTime(s.now())
+	}
+	if isOn(q, "gs") {
+		hd = hd.Next() // after sunset
+	}
+	dateKey := ""
+	if relative {
+		dateKey = hd.String()
+	}
+	if checkCache(w, r, makeETag(r.URL.Path, q, formatJSON, dateKey), relative) {
+		return
+	}
+	gy, gm, gd := hd.Greg()
+	resp := converterResponse{
+		Gy:     gy,
+		Gm:     int(gm),
+		Gd:     gd,
+		Hy:     hd.Year(),
+		Hm:     hd.MonthName("en"),
+		Hd:     hd.Day(),
+		Hebrew: event.NewHebrewDateEvent(hd).Render("he"),
+		Events: []string{},
+	}
+	for _, ev := range hebcal.GetHolidaysForYear(hd.Year(), opts.IL) {
+		if ev.Date == hd {
+			resp.Events = append(resp.Events, ev.Render(opts.Locale))
+		}
+	}
+	writeJSON(w, resp)
+}
+
+type zmanimResponse struct {
+	Date     string               `json:"date"`
+	Location *hebcal.JSONLocation `json:"location"`
+	Times    map[string]string    `json:"times"`
+}
+
+func (s *server) handleZmanim(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	parsed, err := hebcal.ParseQuery(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	loc := parsed.Location
+	if loc == nil {
+		writeError(w, http.StatusBadRequest, errors.New("zmanim requires a location"))
+		return
+	}
+	hd, hasDate, err := parseDate(q, "date")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	tz, _ := time.LoadLocation(loc.TimeZoneId)
+	if !hasDate {
+		hd = hdate.FromTime(s.now().In(tz))
+	}
+	dateKey := ""
+	if !hasDate {
+		dateKey = hd.String()
+	}
+	if checkCache(w, r, makeETag(r.URL.Path, q, formatJSON, dateKey), !hasDate) {
+		return
+	}
+	z := zmanim.New(loc, hd.Gregorian())
+	times := map[string]time.Time{
+		"chatzotNight":      z.ChatzotNight(),
+		"alotHaShachar":     z.AlotHaShachar(),
+		"misheyakir":        z.Misheyakir(),
+		"misheyakirMachmir": z.MisheyakirMachmir(),
+		"dawn":              z.Dawn(),
+		"sunrise":           z.Sunrise(),
+		"sofZmanShma":       z.SofZmanShma(),
+		"sofZmanShmaMGA":    z.SofZmanShmaMGA(),
+		"sofZmanTfilla":     z.SofZmanTfilla(),
+		"sofZmanTfillaMGA":  z.SofZmanTfillaMGA(),
+		"chatzot":           z.Chatzot(),
+		"minchaGedola":      z.MinchaGedola(),
+		"minchaKetana":      z.MinchaKetana(),
+		"plagHaMincha":      z.PlagHaMincha(),
+		"sunset":            z.Sunset(),
+		"dusk":              z.Dusk(),
+		"tzeit7083deg":      z.Tzeit(zmanim.Tzeit3MediumStars),
+		"tzeit85deg":        z.Tzeit(zmanim.Tzeit3SmallStars),
+	}
+	resp := zmanimResponse{
+		Date: hd.Gregorian().Format("2006-01-02"),
+		Location: &hebcal.JSONLocation{
+			Title:       loc.Name,
+			City:        loc.Name,
+			TimeZoneId:  loc.TimeZoneId,
+			Latitude:    loc.Latitude,
+			Longitude:   loc.Longitude,
+			CountryCode: loc.CountryCode,
+		},
+		Times: make(map[string]string, len(times)),
+	}
+	for name, t := range times {
+		if !t.IsZero() {
+			resp.Times[name] = t.Format(time.RFC3339)
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// handleShabbat returns candle-lighting, Torah reading, holidays
+// and Havdalah from the given date (default today) through the
+// following Saturday, or the end of an adjacent Yom Tov.
+func (s *server) handleShabbat(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format, err := negotiateFormat(r)
+	if err != nil {
+		writeError(w, http.StatusNotAcceptable, err)
+		return
+	}
+	parsed, err := hebcal.ParseQuery(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	loc := parsed.Location
+	if loc == nil {
+		writeError(w, http.StatusBadRequest, errors.New("shabbat requires a location"))
+		return
+	}
+	hd, hasDate, err := parseDate(q, "date")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if !hasDate {
+		tz, _ := time.LoadLocation(loc.TimeZoneId)
+		hd = hdate.FromTime(s.now().In(tz))
+	}
+	dateKey := ""
+	if !hasDate {
+		dateKey = hd.String()
+	}
+	if checkCache(w, r, makeETag(r.URL.Path, q, format, dateKey), !hasDate) {
+		return
+	}
+	week, err := hebcal.ShabbatSummary(loc, hd, parsed)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	opts := hebcal.CalOptions{Start: week.Start, End: week.End, Location: loc}
+	writeEvents(w, week.Events, &opts, parsed.Locale, format)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixedNow() time.Time {
+	return time.Date(2022, time.September, 20, 12, 0, 0, 0, time.UTC)
+}
+
+func doGet(t *testing.T, target string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	newServer(fixedNow).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHebcalJSON(t *testing.T) {
+	rec := doGet(t, "/hebcal?v=1&cfg=json&maj=on&start=2022-10-05&end=2022-10-05", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, cacheStable, rec.Header().Get("Cache-Control"))
+	var doc struct {
+		Items []struct {
+			Title string `json:"title"`
+			Date  string `json:"date"`
+		} `json:"items"`
+	}
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, 1, len(doc.Items))
+	assert.Equal(t, "Yom Kippur", doc.Items[0].Title)
+	assert.Equal(t, "2022-10-05", doc.Items[0].Date)
+}
+
+func TestHebcalContentNegotiation(t *testing.T) {
+	target := "/hebcal?v=1&maj=on&start=2022-10-05&end=2022-10-05"
+	rec := doGet(t, target, map[string]string{"Accept": "text/calendar"})
+	assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.Contains(rec.Body.String(), "SUMMARY:Yom Kippur\r\n"))
+
+	rec = doGet(t, target, map[string]string{"Accept": "text/csv"})
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.Contains(rec.Body.String(), "Yom Kippur,10/5/2022,"))
+
+	rec = doGet(t, target+"&cfg=csv", map[string]string{"Accept": "text/calendar"})
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	rec = doGet(t, target+"&cfg=xml", nil)
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+}
+
+func TestHebcalETag(t *testing.T) {
+	rec1 := doGet(t, "/hebcal?maj=on&year=2022&month=10", nil)
+	rec2 := doGet(t, "/hebcal?month=10&year=2022&maj=on", nil)
+	etag := rec1.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Equal(t, etag, rec2.Header().Get("ETag"))
+	rec3 := doGet(t, "/hebcal?maj=on&year=2022&month=11", nil)
+	assert.NotEqual(t, etag, rec3.Header().Get("ETag"))
+	rec4 := doGet(t, "/hebcal?maj=on&year=2022&month=10", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, rec4.Code)
+	assert.Equal(t, 0, rec4.Body.Len())
+}
+
+func TestHebcalRelativeCache(t *testing.T) {
+	rec := doGet(t, "/hebcal?maj=on&year=now", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, cacheRelative, rec.Header().Get("Cache-Control"))
+}
+
+func TestHebcalErrors(t *testing.T) {
+	for _, target := range []string{
+		"/hebcal?year=abc",
+		"/hebcal?c=on",
+		"/hebcal?geo=city&city=Nowhere",
+		"/hebcal?start=2022-10-05",
+		"/hebcal?start=2022-10-05&end=2022-10-01",
+		"/hebcal?geo=pos&latitude=100&longitude=0&tzid=UTC",
+	} {
+		rec := doGet(t, target, nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		var body map[string]string
+		assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &body), target)
+		assert.NotEqual(t, "", body["error"], target)
+	}
+}
+
+func TestConverter(t *testing.T) {
+	rec := doGet(t, "/converter?gy=2022&gm=10&gd=5", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp converterResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 5783, resp.Hy)
+	assert.Equal(t, "Tishrei", resp.Hm)
+	assert.Equal(t, 10, resp.Hd)
+	assert.Equal(t, []string{"Yom Kippur"}, resp.Events)
+
+	rec = doGet(t, "/converter?h2g=1&hy=5783&hm=Tishrei&hd=10", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	resp = converterResponse{}
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2022, resp.Gy)
+	assert.Equal(t, 10, resp.Gm)
+	assert.Equal(t, 5, resp.Gd)
+
+	rec = doGet(t, "/converter?gy=2022&gm=2&gd=30", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestZmanim(t *testing.T) {
+	rec := doGet(t, "/zmanim?geo=city&city=Jerusalem&date=2022-10-05", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp zmanimResponse
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "2022-10-05", resp.Date)
+	assert.Equal(t, "Jerusalem", resp.Location.Title)
+	sunset, err := time.Parse(time.RFC3339, resp.Times["sunset"])
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "+03:00", sunset.Format("-07:00"))
+
+	rec = doGet(t, "/zmanim?date=2022-10-05", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestShabbat(t *testing.T) {
+	rec := doGet(t, "/shabbat?geo=city&city=Chicago&date=2022-09-20", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var doc struct {
+		Items []struct {
+			Title    string `json:"title"`
+			Category string `json:"category"`
+		} `json:"items"`
+	}
+	assert.Equal(t, nil, json.Unmarshal(rec.Body.Bytes(), &doc))
+	categories := make([]string, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		categories = append(categories, item.Category)
+	}
+	assert.Equal(t, []string{"candles", "parashat", "havdalah"}, categories)
+	assert.Equal(t, "Parashat Nitzavim", doc.Items[1].Title)
+
+	rec = doGet(t, "/shabbat?date=2022-09-20", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,199 @@
+/*
+Hebcal prints a list of Jewish holidays and other calendar events
+for a Gregorian or Hebrew year.
+
+It accepts the same flags as the classic C version of hebcal, so
+existing scripts can switch to it unchanged.
+
+Usage:
+
+	hebcal [options] [[month [day]] year]
+
+Examples:
+
+	hebcal -sc -C Chicago 2022     # holidays, sedrot and candle-lighting for 2022
+	hebcal -H Nisan 5783           # holidays in Nisan 5783
+	hebcal -t                      # today's Hebrew date and events
+	hebcal -c -l 41,49N -L 71,25W -z America/New_York
+
+Run "hebcal --help" for the full list of options.
+*/
+package main
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+// Derived from original C version, Copyright (C) 1994-2004 Danny Sadinoff
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+)
+
+const version = "hebcal-go 1.0"
+
+const usage = `usage: hebcal [options] [[month [day]] year]
+
+Options:
+
+  -a, --ashkenazi                   use Ashkenazi transliterations
+  -b, --candle-mins MINS            light candles MINS before sundown (default 18)
+  -c, --candlelighting              print candle-lighting and Havdalah times
+                                    (for New York, or $HEBCAL_CITY, by default)
+  -C, --city CITY                   city for candle-lighting (implies -c)
+  -d, --add-hebrew-dates            print the Hebrew date for every day
+  -D, --add-hebrew-dates-for-events print the Hebrew date for days with events
+  -e, --euro-dates                  output dates as D.M.YYYY
+  -E, --24hour                      use 24-hour time
+  -F, --daf-yomi                    include Daf Yomi
+  -g, --iso-8601                    output dates as YYYY-MM-DD
+  -h, --no-holidays                 suppress default holidays
+  -H, --hebrew-date                 interpret year (and month) as Hebrew
+  -i, --israeli                     use the Israeli holiday and sedra schedule
+  -l, --latitude DEG,MIN[NS]        latitude for candle-lighting (positive is north)
+  -L, --longitude DEG,MIN[EW]       longitude for candle-lighting (positive is WEST)
+  -m, --havdalah-mins MINS          Havdalah MINS after sundown
+  -M, --molad                       print the molad on Shabbat Mevarchim
+  -o, --omer                        include days of the Omer
+  -O, --sunrise-and-sunset          print sunrise and sunset every day
+  -r, --tabs                        separate date and title with a tab
+  -s, --sedrot                      include the weekly sedra on Saturdays
+  -S, --daily-sedra                 print the upcoming sedra every day
+  -t, --today                       print only today's date and events
+  -w, --weekday                     print the weekday before the date
+  -W, --abbreviated                 weekly abbreviated view
+  -x, --no-rosh-chodesh             suppress Rosh Chodesh
+  -y, --year-abbrev                 print two-digit years
+  -z, --timezone TZID               timezone for latitude/longitude
+  -Z, --zmanim                      print daily zmanim
+      --lang LOCALE                 output language (e.g. he, ru, fr)
+      --years N                     generate N years (default 1)
+      --havdalah-deg DEG            Havdalah at DEG degrees below the horizon
+      --no-modern                   suppress modern Israeli holidays
+      --no-minor-fast               suppress minor fasts
+      --no-special                  suppress special Shabbatot
+      --shabbat-mevarchim           include Shabbat Mevarchim HaChodesh
+      --mishna-yomi                 include Mishna Yomi
+      --nach-yomi                   include Nach Yomi
+      --rambam                      include Rambam Yomi (3 chapters a day)
+      --rambam1                     include Rambam Yomi (1 chapter a day)
+      --amud-yomi                   include Amud Yomi (one side of a daf a day)
+      --daf-weekly                  include Daf HaShavua (one daf a week)
+      --929                         include 929 (a chapter of Tanakh, Sunday-Thursday)
+      --tehillim                    include daily Tehillim (monthly cycle)
+      --tehillim-weekly             include daily Tehillim (weekly cycle)
+      --yerushalmi                  include Yerushalmi Yomi (Vilna)
+      --schottenstein               include Yerushalmi Yomi (Schottenstein)
+      --yomkippurkatan              include Yom Kippur Katan
+      --weekday-readings            include Monday, Thursday and Shabbat Mincha Torah readings
+      --pirkei-avot                 include Pirkei Avot on summer Shabbat afternoons
+      --both-schedules              include Israel and Diaspora schedules, marking differences
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, time.Now()))
+}
+
+// run executes the program and returns the process exit status.
+// now is used for -t and for the default year.
+func run(args []string, stdout, stderr io.Writer, now time.Time) int {
+	cfg := config{locale: "en"}
+	positional, err := parseArgs(args, &cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "hebcal: %v\nTry 'hebcal --help' for more information.\n", err)
+		return 2
+	}
+	if cfg.help {
+		fmt.Fprint(stdout, usage)
+		return 0
+	}
+	if cfg.version {
+		fmt.Fprintln(stdout, version)
+		return 0
+	}
+	if err := resolveLocation(&cfg); err != nil {
+		fmt.Fprintf(stderr, "hebcal: %v\n", err)
+		return 1
+	}
+	if cfg.today {
+		if len(positional) != 0 {
+			fmt.Fprintln(stderr, "hebcal: -t cannot be combined with a date")
+			return 2
+		}
+		today := hdate.FromTime(now)
+		cfg.opts.Start = today
+		cfg.opts.End = today
+		cfg.opts.AddHebrewDates = true
+	} else if err := parseDateArgs(positional, &cfg); err != nil {
+		fmt.Fprintf(stderr, "hebcal: %v\n", err)
+		return 2
+	}
+	if cfg.opts.Year == 0 && (cfg.opts.Start == hdate.HDate{}) {
+		if cfg.opts.IsHebrewYear {
+			cfg.opts.Year = hdate.FromTime(now).Year()
+		} else {
+			cfg.opts.Year = now.Year()
+		}
+	}
+	w := bufio.NewWriter(stdout)
+	err = hebcal.HebrewCalendarFunc(context.Background(), &cfg.opts, func(ev event.CalEvent) error {
+		writeEvent(w, ev, &cfg)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "hebcal: %v\n", err)
+		return 1
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "hebcal: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func writeEvent(w io.Writer, ev event.CalEvent, cfg *config) {
+	t := ev.GetDate().Gregorian()
+	sep := " "
+	if cfg.tabs {
+		sep = "\t"
+	}
+	line := formatDate(t, cfg.dates) + sep + ev.Render(cfg.locale)
+	if cfg.weekday {
+		line = t.Weekday().String()[0:3] + " " + line
+	}
+	fmt.Fprintln(w, line)
+}
+
+func formatDate(t time.Time, format dateFormat) string {
+	year, month, day := t.Date()
+	switch format {
+	case dateEuro:
+		return strconv.Itoa(day) + "." + strconv.Itoa(int(month)) + "." + strconv.Itoa(year)
+	case dateISO:
+		return t.Format("2006-01-02")
+	case dateAbbrev:
+		return strconv.Itoa(int(month)) + "/" + strconv.Itoa(day) + "/" + fmt.Sprintf("%02d", year%100)
+	}
+	return strconv.Itoa(int(month)) + "/" + strconv.Itoa(day) + "/" + strconv.Itoa(year)
+}
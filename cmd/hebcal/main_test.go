@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runHebcal(args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	now := time.Date(2022, time.December, 25, 12, 0, 0, 0, time.UTC)
+	status := run(args, &stdout, &stderr, now)
+	return stdout.String(), stderr.String(), status
+}
+
+func TestCandleLightingAndSedrot(t *testing.T) {
+	out, _, status := runHebcal("-sc", "-C", "Chicago", "-m", "50", "4", "2022")
+	assert.Equal(t, 0, status)
+	lines := strings.Split(out, "\n")
+	expected := []string{
+		"4/1/2022 Candle lighting: 6:57",
+		"4/2/2022 Parashat Tazria",
+		"4/2/2022 Rosh Chodesh Nisan",
+		"4/2/2022 Shabbat HaChodesh",
+		"4/2/2022 Havdalah (50 min): 8:06",
+	}
+	assert.Equal(t, expected, lines[0:5])
+}
+
+func TestHebrewMonthWeekday(t *testing.T) {
+	out, _, status := runHebcal("-Hwx", "Nisan", "5783")
+	assert.Equal(t, 0, status)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Equal(t, "Sat 4/1/2023 Shabbat HaGadol", lines[0])
+	assert.NotContains(t, out, "Rosh Chodesh")
+}
+
+func TestDateFormats(t *testing.T) {
+	out, _, _ := runHebcal("-g", "--lang=he", "12", "25", "2022")
+	assert.Equal(t, "2022-12-25 חֲנוּכָּה: ח׳ נֵרוֹת\n2022-12-25 רֹאשׁ חוֹדֶשׁ טֵבֵת\n", out)
+	out, _, _ = runHebcal("-e", "-r", "12", "25", "2022")
+	assert.Equal(t, "25.12.2022\tChanukah: 8 Candles\n25.12.2022\tRosh Chodesh Tevet\n", out)
+	out, _, _ = runHebcal("-y", "12", "25", "2022")
+	assert.Equal(t, "12/25/22 Chanukah: 8 Candles\n12/25/22 Rosh Chodesh Tevet\n", out)
+}
+
+func TestToday(t *testing.T) {
+	out, _, status := runHebcal("-t")
+	assert.Equal(t, 0, status)
+	assert.Equal(t, "12/25/2022 1st of Tevet, 5783\n"+
+		"12/25/2022 Chanukah: 8 Candles\n"+
+		"12/25/2022 Rosh Chodesh Tevet\n", out)
+}
+
+func TestLatLong(t *testing.T) {
+	out, _, status := runHebcal("-c", "-l", "41,49N", "-L", "71,25W", "-z", "America/New_York", "1", "2022")
+	assert.Equal(t, 0, status)
+	assert.True(t, strings.HasPrefix(out, "1/1/2022 Havdalah: 5:11\n"))
+}
+
+// Invocations as found in scripts written for the classic C hebcal
+func TestClassicLocationFlags(t *testing.T) {
+	// unsuffixed longitude is positive to the west
+	classic, _, status := runHebcal("-c", "-l", "41,49", "-L", "71,25", "-z", "America/New_York", "1", "2022")
+	assert.Equal(t, 0, status)
+	suffixed, _, _ := runHebcal("-c", "-l", "41,49N", "-L", "71,25W", "-z", "America/New_York", "1", "2022")
+	assert.Equal(t, suffixed, classic)
+
+	// and negative to the east
+	classic, _, status = runHebcal("-c", "-l", "31,47", "-L", "-35,14", "-z", "Asia/Jerusalem", "1", "2022")
+	assert.Equal(t, 0, status)
+	suffixed, _, _ = runHebcal("-c", "-l", "31,47N", "-L", "35,14E", "-z", "Asia/Jerusalem", "1", "2022")
+	assert.Equal(t, suffixed, classic)
+
+	// -c without a location uses the default city
+	if city, ok := os.LookupEnv("HEBCAL_CITY"); ok {
+		defer os.Setenv("HEBCAL_CITY", city)
+	} else {
+		defer os.Unsetenv("HEBCAL_CITY")
+	}
+	os.Setenv("HEBCAL_CITY", "")
+	out, _, status := runHebcal("-c", "2022")
+	assert.Equal(t, 0, status)
+	newYork, _, _ := runHebcal("-C", "New York", "2022")
+	assert.Equal(t, newYork, out)
+	assert.Contains(t, out, "1/7/2022 Candle lighting: 4:26")
+	os.Setenv("HEBCAL_CITY", "Chicago")
+	out, _, _ = runHebcal("-sc", "4", "2022")
+	chicago, _, _ := runHebcal("-sc", "-C", "Chicago", "4", "2022")
+	assert.Equal(t, chicago, out)
+}
+
+func TestErrors(t *testing.T) {
+	_, stderr, status := runHebcal("-q")
+	assert.Equal(t, 2, status)
+	assert.Contains(t, stderr, "invalid option -- 'q'")
+	_, stderr, status = runHebcal("-c", "-l", "41,49", "2022")
+	assert.Equal(t, 1, status)
+	assert.Contains(t, stderr, "both latitude (-l) and longitude (-L) are required")
+	_, stderr, status = runHebcal("-C", "Atlantis")
+	assert.Equal(t, 1, status)
+	assert.Contains(t, stderr, "unknown city")
+	_, stderr, status = runHebcal("13", "2022")
+	assert.Equal(t, 2, status)
+	assert.Contains(t, stderr, "invalid month")
+}
+
+func TestParseDegrees(t *testing.T) {
+	deg, err := parseDegrees("41,30N", 'N', 'S')
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 41.5, deg)
+	deg, err = parseDegrees("71,15w", 'E', 'W')
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -71.25, deg)
+	deg, err = parseDegrees("71,15", 'W', 'E')
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 71.25, deg)
+	deg, err = parseDegrees("-33", 'N', 'S')
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -33.0, deg)
+	_, err = parseDegrees("41,75N", 'N', 'S')
+	assert.NotEqual(t, nil, err)
+}
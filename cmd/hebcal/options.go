@@ -0,0 +1,421 @@
+package main
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+// Derived from original C version, Copyright (C) 1994-2004 Danny Sadinoff
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/greg"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/rambam"
+	"github.com/MaxBGreenberg/hebcal-go/yerushalmi"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+)
+
+// dateFormat controls how the date column of each output line looks.
+type dateFormat int
+
+const (
+	dateUSA    dateFormat = iota // 4/16/2022
+	dateEuro                     // 16.4.2022
+	dateISO                      // 2022-04-16
+	dateAbbrev                   // 4/16/22
+)
+
+// config holds everything parsed from the command line that
+// isn't a field of hebcal.CalOptions.
+type config struct {
+	opts       hebcal.CalOptions
+	locale     string
+	dates      dateFormat
+	weekday    bool
+	tabs       bool
+	today      bool
+	help       bool
+	version    bool
+	cityName   string
+	latitude   string
+	longitude  string
+	tzid       string
+	hasLatLong bool
+}
+
+type optionSpec struct {
+	short  byte   // single-letter flag, or 0
+	long   string // long flag name without leading dashes, or ""
+	hasArg bool
+	apply  func(cfg *config, arg string) error
+}
+
+func setBool(f func(cfg *config)) func(cfg *config, arg string) error {
+	return func(cfg *config, arg string) error {
+		f(cfg)
+		return nil
+	}
+}
+
+func setInt(name string, f func(cfg *config, n int)) func(cfg *config, arg string) error {
+	return func(cfg *config, arg string) error {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", name, arg)
+		}
+		f(cfg, n)
+		return nil
+	}
+}
+
+var optionSpecs = []optionSpec{
+	{'a', "ashkenazi", false, setBool(func(cfg *config) { cfg.locale = "ashkenazi" })},
+	{'b', "candle-mins", true, setInt("candle-lighting minutes", func(cfg *config, n int) { cfg.opts.CandleLightingMins = n })},
+	{'c', "candlelighting", false, setBool(func(cfg *config) { cfg.opts.CandleLighting = true })},
+	{'C', "city", true, func(cfg *config, arg string) error {
+		cfg.cityName = arg
+		cfg.opts.CandleLighting = true
+		return nil
+	}},
+	{'d', "add-hebrew-dates", false, setBool(func(cfg *config) { cfg.opts.AddHebrewDates = true })},
+	{'D', "add-hebrew-dates-for-events", false, setBool(func(cfg *config) { cfg.opts.AddHebrewDatesForEvents = true })},
+	{'e', "euro-dates", false, setBool(func(cfg *config) { cfg.dates = dateEuro })},
+	{'E', "24hour", false, setBool(func(cfg *config) { cfg.opts.Hour24 = true })},
+	{'F', "daf-yomi", false, setBool(func(cfg *config) { cfg.opts.DafYomi = true })},
+	{'g', "iso-8601", false, setBool(func(cfg *config) { cfg.dates = dateISO })},
+	{'h', "no-holidays", false, setBool(func(cfg *config) { cfg.opts.NoHolidays = true })},
+	{'H', "hebrew-date", false, setBool(func(cfg *config) { cfg.opts.IsHebrewYear = true })},
+	{'i', "israeli", false, setBool(func(cfg *config) { cfg.opts.IL = true })},
+	{'l', "latitude", true, func(cfg *config, arg string) error {
+		cfg.latitude = arg
+		cfg.hasLatLong = true
+		return nil
+	}},
+	{'L', "longitude", true, func(cfg *config, arg string) error {
+		cfg.longitude = arg
+		cfg.hasLatLong = true
+		return nil
+	}},
+	{'m', "havdalah-mins", true, setInt("havdalah minutes", func(cfg *config, n int) { cfg.opts.HavdalahMins = n })},
+	{'M', "molad", false, setBool(func(cfg *config) { cfg.opts.Molad = true })},
+	{'o', "omer", false, setBool(func(cfg *config) { cfg.opts.Omer = true })},
+	{'O', "sunrise-and-sunset", false, setBool(func(cfg *config) { cfg.opts.SunriseSunset = true })},
+	{'r', "tabs", false, setBool(func(cfg *config) { cfg.tabs = true })},
+	{'s', "sedrot", false, setBool(func(cfg *config) { cfg.opts.Sedrot = true })},
+	{'S', "daily-sedra", false, setBool(func(cfg *config) { cfg.opts.DailySedra = true })},
+	{'t', "today", false, setBool(func(cfg *config) { cfg.today = true })},
+	{'w', "weekday", false, setBool(func(cfg *config) { cfg.weekday = true })},
+	{'W', "abbreviated", false, setBool(func(cfg *config) { cfg.opts.WeeklyAbbreviated = true })},
+	{'x', "no-rosh-chodesh", false, setBool(func(cfg *config) { cfg.opts.NoRoshChodesh = true })},
+	{'y', "year-abbrev", false, setBool(func(cfg *config) { cfg.dates = dateAbbrev })},
+	{'z', "timezone", true, func(cfg *config, arg string) error {
+		cfg.tzid = arg
+		return nil
+	}},
+	{'Z', "zmanim", false, setBool(func(cfg *config) { cfg.opts.DailyZmanim = true })},
+	{0, "lang", true, func(cfg *config, arg string) error {
+		cfg.locale = arg
+		return nil
+	}},
+	{0, "years", true, setInt("number of years", func(cfg *config, n int) { cfg.opts.NumYears = n })},
+	{0, "havdalah-deg", true, func(cfg *config, arg string) error {
+		deg, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid havdalah degrees %q", arg)
+		}
+		cfg.opts.HavdalahDeg = deg
+		return nil
+	}},
+	{0, "no-modern", false, setBool(func(cfg *config) { cfg.opts.NoModern = true })},
+	{0, "no-minor-fast", false, setBool(func(cfg *config) { cfg.opts.NoMinorFast = true })},
+	{0, "no-special", false, setBool(func(cfg *config) { cfg.opts.NoSpecialShabbat = true })},
+	{0, "shabbat-mevarchim", false, setBool(func(cfg *config) { cfg.opts.ShabbatMevarchim = true })},
+	{0, "mishna-yomi", false, setBool(func(cfg *config) { cfg.opts.MishnaYomi = true })},
+	{0, "nach-yomi", false, setBool(func(cfg *config) { cfg.opts.NachYomi = true })},
+	{0, "rambam", false, setBool(func(cfg *config) { cfg.opts.RambamYomi = true })},
+	{0, "rambam1", false, setBool(func(cfg *config) {
+		cfg.opts.RambamYomi = true
+		cfg.opts.RambamCycle = rambam.OneChapter
+	})},
+	{0, "amud-yomi", false, setBool(func(cfg *config) { cfg.opts.AmudYomi = true })},
+	{0, "daf-weekly", false, setBool(func(cfg *config) { cfg.opts.DafWeekly = true })},
+	{0, "929", false, setBool(func(cfg *config) { cfg.opts.Tanakh929 = true })},
+	{0, "tehillim", false, setBool(func(cfg *config) { cfg.opts.TehillimMonthly = true })},
+	{0, "tehillim-weekly", false, setBool(func(cfg *config) { cfg.opts.TehillimWeekly = true })},
+	{0, "yerushalmi", false, setBool(func(cfg *config) { cfg.opts.YerushalmiYomi = true })},
+	{0, "schottenstein", false, setBool(func(cfg *config) {
+		cfg.opts.YerushalmiYomi = true
+		cfg.opts.YerushalmiEdition = yerushalmi.Schottenstein
+	})},
+	{0, "yomkippurkatan", false, setBool(func(cfg *config) { cfg.opts.YomKippurKatan = true })},
+	{0, "weekday-readings", false, setBool(func(cfg *config) { cfg.opts.WeekdayReadings = true })},
+	{0, "pirkei-avot", false, setBool(func(cfg *config) { cfg.opts.PirkeiAvot = true })},
+	{0, "both-schedules", false, setBool(func(cfg *config) { cfg.opts.BothSchedules = true })},
+	{0, "help", false, setBool(func(cfg *config) { cfg.help = true })},
+	{0, "version", false, setBool(func(cfg *config) { cfg.version = true })},
+}
+
+func findShort(c byte) *optionSpec {
+	for i := range optionSpecs {
+		if optionSpecs[i].short == c {
+			return &optionSpecs[i]
+		}
+	}
+	return nil
+}
+
+func findLong(name string) *optionSpec {
+	for i := range optionSpecs {
+		if optionSpecs[i].long == name {
+			return &optionSpecs[i]
+		}
+	}
+	return nil
+}
+
+// parseArgs parses command-line flags in the style of getopt_long,
+// so that combined short flags like "-sc" work as they did with the
+// classic C version of hebcal. Returns the remaining positional args.
+func parseArgs(args []string, cfg *config) ([]string, error) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if strings.HasPrefix(arg, "--") {
+			name := arg[2:]
+			value := ""
+			hasValue := false
+			if eq := strings.IndexByte(name, '='); eq != -1 {
+				name, value, hasValue = name[:eq], name[eq+1:], true
+			}
+			spec := findLong(name)
+			if spec == nil {
+				return nil, fmt.Errorf("unrecognized option '--%s'", name)
+			}
+			if spec.hasArg && !hasValue {
+				if i+1 >= len(args) {
+					return nil, fmt.Errorf("option '--%s' requires an argument", name)
+				}
+				i++
+				value = args[i]
+			}
+			if err := spec.apply(cfg, value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if len(arg) < 2 || arg[0] != '-' || isNumber(arg) {
+			positional = append(positional, arg)
+			continue
+		}
+		for j := 1; j < len(arg); j++ {
+			spec := findShort(arg[j])
+			if spec == nil {
+				return nil, fmt.Errorf("invalid option -- '%c'", arg[j])
+			}
+			value := ""
+			if spec.hasArg {
+				if j+1 < len(arg) {
+					value = arg[j+1:]
+				} else if i+1 < len(args) {
+					i++
+					value = args[i]
+				} else {
+					return nil, fmt.Errorf("option requires an argument -- '%c'", arg[j])
+				}
+				j = len(arg)
+			}
+			if err := spec.apply(cfg, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return positional, nil
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// parseDegrees parses a coordinate like "41,49N" or "71,25W"
+// (degrees, minutes and an optional hemisphere) into decimal degrees.
+// A coordinate without a hemisphere is positive toward pos.
+func parseDegrees(s string, pos, neg byte) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty coordinate")
+	}
+	sign := 1.0
+	last := s[len(s)-1]
+	switch {
+	case last == pos || last == pos+('a'-'A'):
+		s = s[:len(s)-1]
+	case last == neg || last == neg+('a'-'A'):
+		sign = -1.0
+		s = s[:len(s)-1]
+	}
+	parts := strings.SplitN(s, ",", 2)
+	deg, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinate %q", s)
+	}
+	if deg < 0 {
+		sign = -sign
+		deg = -deg
+	}
+	var min float64
+	if len(parts) == 2 {
+		min, err = strconv.ParseFloat(parts[1], 64)
+		if err != nil || min < 0 || min >= 60 {
+			return 0, fmt.Errorf("invalid coordinate %q", s)
+		}
+	}
+	return sign * (deg + min/60.0), nil
+}
+
+// defaultCity is used for candle-lighting and zmanim when no location
+// is given, unless overridden by the HEBCAL_CITY environment variable,
+// as in the classic C version of hebcal.
+const defaultCity = "New York"
+
+// resolveLocation fills in cfg.opts.Location from -C or -l/-L/-z.
+//
+// As in classic hebcal, an unsuffixed latitude is positive to the north
+// and an unsuffixed longitude is positive to the WEST, so
+// "-l 41,49 -L 71,25" is Providence, Rhode Island.
+func resolveLocation(cfg *config) error {
+	if !cfg.hasLatLong && cfg.cityName == "" &&
+		(cfg.opts.CandleLighting || cfg.opts.SunriseSunset || cfg.opts.DailyZmanim) {
+		cfg.cityName = os.Getenv("HEBCAL_CITY")
+		if cfg.cityName == "" {
+			cfg.cityName = defaultCity
+		}
+	}
+	if cfg.cityName != "" {
+		loc := zmanim.LookupCity(cfg.cityName)
+		if loc == nil {
+			return fmt.Errorf("unknown city %q", cfg.cityName)
+		}
+		cfg.opts.Location = loc
+		return nil
+	}
+	if !cfg.hasLatLong {
+		return nil
+	}
+	if cfg.latitude == "" || cfg.longitude == "" {
+		return errors.New("both latitude (-l) and longitude (-L) are required")
+	}
+	if cfg.tzid == "" {
+		return errors.New("latitude/longitude requires a timezone (-z)")
+	}
+	if _, err := time.LoadLocation(cfg.tzid); err != nil {
+		return fmt.Errorf("unknown timezone %q", cfg.tzid)
+	}
+	lat, err := parseDegrees(cfg.latitude, 'N', 'S')
+	if err != nil {
+		return err
+	}
+	west, err := parseDegrees(cfg.longitude, 'W', 'E')
+	if err != nil {
+		return err
+	}
+	long := -west
+	if lat < -90 || lat > 90 || long < -180 || long > 180 {
+		return errors.New("latitude or longitude out of range")
+	}
+	loc := zmanim.NewLocation("User Defined City", "", lat, long, cfg.tzid)
+	cfg.opts.Location = &loc
+	cfg.opts.CandleLighting = true
+	return nil
+}
+
+// parseDateArgs interprets the positional [[month [day]] year] arguments.
+func parseDateArgs(positional []string, cfg *config) error {
+	opts := &cfg.opts
+	switch len(positional) {
+	case 0:
+		return nil
+	case 1:
+		year, err := strconv.Atoi(positional[0])
+		if err != nil {
+			return fmt.Errorf("invalid year %q", positional[0])
+		}
+		opts.Year = year
+		return nil
+	case 2, 3:
+	default:
+		return errors.New("too many arguments; expected [[month [day]] year]")
+	}
+	yearStr := positional[len(positional)-1]
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return fmt.Errorf("invalid year %q", yearStr)
+	}
+	day := 0
+	if len(positional) == 3 {
+		day, err = strconv.Atoi(positional[1])
+		if err != nil || day < 1 {
+			return fmt.Errorf("invalid day %q", positional[1])
+		}
+	}
+	monthStr := positional[0]
+	if opts.IsHebrewYear {
+		month, err := hdate.MonthFromName(monthStr)
+		if err != nil {
+			return fmt.Errorf("unknown Hebrew month %q", monthStr)
+		}
+		if month == hdate.Adar2 && !hdate.IsLeapYear(year) {
+			month = hdate.Adar1
+		}
+		numDays := hdate.DaysInMonth(month, year)
+		if day > numDays {
+			return fmt.Errorf("%s %d has only %d days", monthStr, year, numDays)
+		}
+		if day != 0 {
+			opts.Start = hdate.New(year, month, day)
+			opts.End = opts.Start
+		} else {
+			opts.Start = hdate.New(year, month, 1)
+			opts.End = hdate.New(year, month, numDays)
+		}
+		return nil
+	}
+	m, err := strconv.Atoi(monthStr)
+	if err != nil || m < 1 || m > 12 {
+		return fmt.Errorf("invalid month %q", monthStr)
+	}
+	month := time.Month(m)
+	if day != 0 {
+		if day > greg.DaysIn(month, year) {
+			return fmt.Errorf("invalid day %d", day)
+		}
+		opts.Start = hdate.FromGregorian(year, month, day)
+		opts.End = opts.Start
+		return nil
+	}
+	opts.Year = year
+	opts.Month = month
+	return nil
+}
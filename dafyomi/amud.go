@@ -0,0 +1,162 @@
+package dafyomi
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hebcal/greg"
+	"github.com/hebcal/hdate"
+)
+
+// Side is one of the two sides (amudim) of a daf.
+type Side int
+
+const (
+	// Amud aleph, the front of the daf
+	SideA Side = iota
+	// Amud bet, the back of the daf
+	SideB
+)
+
+// Returns "a" or "b"
+func (s Side) String() string {
+	if s == SideB {
+		return "b"
+	}
+	return "a"
+}
+
+// Amud represents one side of a page of Talmud, such as Pesachim 103b
+type Amud struct {
+	Name  string // Tractate name (e.g. Berachot)
+	Blatt int    // Page number
+	Side  Side   // SideA or SideB
+}
+
+// Returns a string representation of the Amud Yomi.
+func (amud Amud) String() string {
+	if amud.Blatt == 0 {
+		return "%!Amud(" + amud.Name + ",0)"
+	}
+	return amud.Name + " " + strconv.Itoa(amud.Blatt) + amud.Side.String()
+}
+
+// Tractates whose last page has text only on amud aleph
+var endsOnSideA = map[string]bool{
+	"Berachot":     true,
+	"Eruvin":       true,
+	"Yoma":         true,
+	"Rosh Hashana": true,
+	"Taanit":       true,
+	"Megillah":     true,
+	"Moed Katan":   true,
+	"Chagigah":     true,
+	"Baba Metzia":  true,
+	"Horayot":      true,
+	"Menachot":     true,
+	"Chullin":      true,
+	"Bechorot":     true,
+	"Arachin":      true,
+	"Temurah":      true,
+	"Meilah":       true,
+	"Kinnim":       true,
+	"Niddah":       true,
+}
+
+// Pages of Kinnim, Tamid and Midot are numbered continuing
+// from the end of Meilah, as in the Vilna Shas.
+var firstBlatt = map[string]int{
+	"Kinnim": 23,
+	"Tamid":  26,
+	"Midot":  34,
+}
+
+// makeDapim returns every daf of Shas in the order of the
+// current Daf Yomi cycle (since 1975).
+func makeDapim() []Daf {
+	dapim := make([]Daf, 0, numDapim)
+	for _, tractate := range shas0 {
+		first := 2
+		last := tractate.Blatt
+		if blatt, ok := firstBlatt[tractate.Name]; ok {
+			first = blatt
+			last = blatt + tractate.Blatt - 2
+		}
+		for blatt := first; blatt <= last; blatt++ {
+			dapim = append(dapim, Daf{Name: tractate.Name, Blatt: blatt})
+		}
+	}
+	return dapim
+}
+
+// makeAmudim returns both sides of every daf, omitting the
+// blank amud bet at the end of some tractates.
+func makeAmudim() []Amud {
+	amudim := make([]Amud, 0, 2*numDapim)
+	for i, daf := range dapim {
+		amudim = append(amudim, Amud{Name: daf.Name, Blatt: daf.Blatt, Side: SideA})
+		lastPage := i+1 == len(dapim) || dapim[i+1].Name != daf.Name
+		if !lastPage || !endsOnSideA[daf.Name] {
+			amudim = append(amudim, Amud{Name: daf.Name, Blatt: daf.Blatt, Side: SideB})
+		}
+	}
+	return amudim
+}
+
+const numDapim = 2711
+
+var dapim = makeDapim()
+var amudim = makeAmudim()
+
+// AmudYomiStart is the R.D. number of the start of the Dirshu
+// Amud Yomi cycle, corresponding to 16 October 2023.
+var AmudYomiStart = greg.ToRD(2023, time.October, 16)
+
+// DafWeeklyStart is the R.D. number of the start of the Daf HaShavua
+// (Daf-a-Week) cycle, corresponding to Sunday, 6 March 2005.
+var DafWeeklyStart = greg.ToRD(2005, time.March, 6)
+
+// NewAmudYomi calculates the Amud Yomi, one side of a daf each day,
+// for given date.
+//
+// Returns an error if the date is before the Amud Yomi cycle began
+// (16 October 2023).
+func NewAmudYomi(hd hdate.HDate) (Amud, error) {
+	abs := hd.Abs()
+	if abs < AmudYomiStart {
+		return Amud{}, errors.New("before Amud Yomi cycle began")
+	}
+	dayNum := (abs - AmudYomiStart) % int64(len(amudim))
+	return amudim[dayNum], nil
+}
+
+// NewDafWeekly calculates the Daf HaShavua, one daf each week from
+// Sunday through Shabbat, for given date.
+//
+// Returns an error if the date is before the Daf HaShavua cycle began
+// (6 March 2005).
+func NewDafWeekly(hd hdate.HDate) (Daf, error) {
+	abs := hd.Abs()
+	if abs < DafWeeklyStart {
+		return Daf{}, errors.New("before Daf HaShavua cycle began")
+	}
+	weekNum := ((abs - DafWeeklyStart) / 7) % numDapim
+	return dapim[weekNum], nil
+}
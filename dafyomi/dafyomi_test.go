@@ -56,3 +56,42 @@ func ExampleNew() {
 	fmt.Println(daf)
 	// Output: Avodah Zarah 68
 }
+
+func TestAmudYomi(t *testing.T) {
+	assert := assert.New(t)
+	amud, err := dafyomi.NewAmudYomi(hdate.FromGregorian(2023, time.October, 16))
+	assert.Nil(err)
+	assert.Equal(dafyomi.Amud{Name: "Berachot", Blatt: 2, Side: dafyomi.SideA}, amud)
+	amud, _ = dafyomi.NewAmudYomi(hdate.FromGregorian(2023, time.October, 17))
+	assert.Equal(dafyomi.Amud{Name: "Berachot", Blatt: 2, Side: dafyomi.SideB}, amud)
+	assert.Equal("Berachot 2b", amud.String())
+	// Berachot ends on 64a, and Shabbat begins the following day
+	amud, _ = dafyomi.NewAmudYomi(hdate.FromGregorian(2024, time.February, 17))
+	assert.Equal("Berachot 64a", amud.String())
+	amud, _ = dafyomi.NewAmudYomi(hdate.FromGregorian(2024, time.February, 18))
+	assert.Equal("Shabbat 2a", amud.String())
+	_, err = dafyomi.NewAmudYomi(hdate.FromGregorian(2023, time.October, 15))
+	assert.Error(err)
+}
+
+func TestDafWeekly(t *testing.T) {
+	assert := assert.New(t)
+	daf, err := dafyomi.NewDafWeekly(hdate.FromGregorian(2005, time.March, 6))
+	assert.Nil(err)
+	assert.Equal(dafyomi.Daf{Name: "Berachot", Blatt: 2}, daf)
+	daf, _ = dafyomi.NewDafWeekly(hdate.FromGregorian(2005, time.March, 12))
+	assert.Equal(dafyomi.Daf{Name: "Berachot", Blatt: 2}, daf)
+	daf, _ = dafyomi.NewDafWeekly(hdate.FromGregorian(2005, time.March, 13))
+	assert.Equal(dafyomi.Daf{Name: "Berachot", Blatt: 3}, daf)
+	// Kinnim, Tamid and Midot continue the page numbers of Meilah
+	daf, _ = dafyomi.NewDafWeekly(hdate.FromRD(dafyomi.DafWeeklyStart + 7*2624))
+	assert.Equal(dafyomi.Daf{Name: "Kinnim", Blatt: 23}, daf)
+	_, err = dafyomi.NewDafWeekly(hdate.FromGregorian(2005, time.March, 5))
+	assert.Error(err)
+}
+
+func ExampleNewAmudYomi() {
+	amud, _ := dafyomi.NewAmudYomi(hdate.FromGregorian(2023, time.November, 17))
+	fmt.Println(amud)
+	// Output: Berachot 18a
+}
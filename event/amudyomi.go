@@ -0,0 +1,83 @@
+package event
+
+import (
+	"strconv"
+
+	"github.com/hebcal/gematriya"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/dafyomi"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+)
+
+type amudYomiEvent struct {
+	Date hdate.HDate
+	Amud dafyomi.Amud
+}
+
+func NewAmudYomiEvent(hd hdate.HDate, amud dafyomi.Amud) CalEvent {
+	return amudYomiEvent{Date: hd, Amud: amud}
+}
+
+func (ev amudYomiEvent) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+// Render returns "Berachot 2a", or in Hebrew "ברכות דף ב׳ ע״א"
+func (ev amudYomiEvent) Render(locale string) string {
+	name, _ := locales.LookupTranslation(ev.Amud.Name, locale)
+	if locale == "he" {
+		side := " ע״א"
+		if ev.Amud.Side == dafyomi.SideB {
+			side = " ע״ב"
+		}
+		return name + " דף " + gematriya.Gematriya(ev.Amud.Blatt) + side
+	}
+	return name + " " + strconv.Itoa(ev.Amud.Blatt) + ev.Amud.Side.String()
+}
+
+func (ev amudYomiEvent) GetFlags() HolidayFlags {
+	return AMUD_YOMI
+}
+
+func (ev amudYomiEvent) GetEmoji() string {
+	return ""
+}
+
+func (ev amudYomiEvent) Basename() string {
+	return ev.Amud.String()
+}
+
+type dafWeeklyEvent struct {
+	Date hdate.HDate
+	Daf  dafyomi.Daf
+}
+
+func NewDafWeeklyEvent(hd hdate.HDate, daf dafyomi.Daf) CalEvent {
+	return dafWeeklyEvent{Date: hd, Daf: daf}
+}
+
+func (ev dafWeeklyEvent) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+// Render returns "Daf HaShavua: Berachot 2"
+func (ev dafWeeklyEvent) Render(locale string) string {
+	prefix, _ := locales.LookupTranslation("Daf HaShavua", locale)
+	name, _ := locales.LookupTranslation(ev.Daf.Name, locale)
+	if locale == "he" {
+		return prefix + ": " + name + " דף " + gematriya.Gematriya(ev.Daf.Blatt)
+	}
+	return prefix + ": " + name + " " + strconv.Itoa(ev.Daf.Blatt)
+}
+
+func (ev dafWeeklyEvent) GetFlags() HolidayFlags {
+	return DAF_WEEKLY
+}
+
+func (ev dafWeeklyEvent) GetEmoji() string {
+	return ""
+}
+
+func (ev dafWeeklyEvent) Basename() string {
+	return ev.Daf.String()
+}
@@ -20,7 +20,7 @@ import "github.com/hebcal/hdate"
 // You should have received a copy of the GNU General Public License
 // along with this program. If not, see <https://www.gnu.org/licenses/>.
 
-type HolidayFlags uint32
+type HolidayFlags uint64
 
 const (
 	// Chag, yontiff, yom tov
@@ -77,6 +77,20 @@ const (
 	YERUSHALMI_YOMI
 	// Daily page of Nach (Nevi'im + Ketuvim)
 	NACH_YOMI
+	// Monday, Thursday and Shabbat Mincha Torah reading
+	WEEKDAY_READING
+	// Chapter of Pirkei Avot on summer Shabbat afternoons
+	PIRKEI_AVOT
+	// Daily portion of Tehillim (Psalms)
+	TEHILLIM_YOMI
+	// Daily chapters of Mishneh Torah (Rambam)
+	RAMBAM_YOMI
+	// Daily chapter of Tanakh in the 929 program
+	TANAKH_929
+	// Daily side (amud) of Talmud (Bavli)
+	AMUD_YOMI
+	// Weekly page of Talmud (Bavli)
+	DAF_WEEKLY
 )
 
 type CalEvent interface {
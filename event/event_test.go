@@ -39,3 +39,16 @@ func TestParshaEvent_Render(t *testing.T) {
 	assert.Equal(t, "Глава Матот-Масей", ev.Render("ru"))
 	assert.Equal(t, "פָּרָשַׁת מַּטּוֹת־מַסְעֵי", ev.Render("he"))
 }
+
+func TestWeekdayReading(t *testing.T) {
+	hd := hdate.New(5783, hdate.Tishrei, 25)
+	aliyot := []string{"Genesis 6:9-16", "Genesis 6:17-19", "Genesis 6:20-22"}
+	ev := event.NewWeekdayReadingEvent(hd, "Noach", "Genesis 6:9-22", aliyot)
+	torah, actual, ok := event.WeekdayReading(ev)
+	assert.True(t, ok)
+	assert.Equal(t, "Genesis 6:9-22", torah)
+	assert.Equal(t, aliyot, actual)
+	assert.Equal(t, "Torah reading: Noach", ev.Render("en"))
+	_, _, ok = event.WeekdayReading(event.NewHebrewDateEvent(hd))
+	assert.False(t, ok)
+}
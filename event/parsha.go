@@ -10,15 +10,31 @@ import (
 
 // Represents one of 54 weekly Torah portions, always on a Saturday
 type parshaEvent struct {
-	Date   hdate.HDate
-	Parsha sedra.Parsha
-	IL     bool
+	Date      hdate.HDate
+	Parsha    sedra.Parsha
+	IL        bool
+	Triennial *sedra.TriennialReading
 }
 
 func NewParshaEvent(hd hdate.HDate, parsha sedra.Parsha, il bool) CalEvent {
 	return parshaEvent{Date: hd, Parsha: parsha, IL: il}
 }
 
+// NewTriennialParshaEvent is like NewParshaEvent, but the event also
+// carries the triennial cycle reading for the week.
+func NewTriennialParshaEvent(hd hdate.HDate, parsha sedra.Parsha, il bool, reading sedra.TriennialReading) CalEvent {
+	return parshaEvent{Date: hd, Parsha: parsha, IL: il, Triennial: &reading}
+}
+
+// Triennial returns the triennial reading of a parsha event created
+// by NewTriennialParshaEvent. ok is false for any other event.
+func Triennial(ev CalEvent) (reading sedra.TriennialReading, ok bool) {
+	if p, isParsha := ev.(parshaEvent); isParsha && p.Triennial != nil {
+		return *p.Triennial, true
+	}
+	return sedra.TriennialReading{}, false
+}
+
 func (ev parshaEvent) GetDate() hdate.HDate {
 	return ev.Date
 }
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"strconv"
+
+	"github.com/hebcal/gematriya"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+)
+
+// Chapter (or chapters) of Pirkei Avot read on Shabbat afternoon
+type pirkeiAvotEvent struct {
+	Date     hdate.HDate
+	Chapters []int
+}
+
+func NewPirkeiAvotEvent(hd hdate.HDate, chapters []int) CalEvent {
+	return pirkeiAvotEvent{Date: hd, Chapters: chapters}
+}
+
+func (ev pirkeiAvotEvent) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+// Render returns "Pirkei Avot 1", or "Pirkei Avot 5-6"
+// when chapters are combined.
+func (ev pirkeiAvotEvent) Render(locale string) string {
+	name, _ := locales.LookupTranslation("Pirkei Avot", locale)
+	first := ev.Chapters[0]
+	last := ev.Chapters[len(ev.Chapters)-1]
+	if locale == "he" {
+		s := name + " " + gematriya.Gematriya(first)
+		if last != first {
+			s += "־" + gematriya.Gematriya(last)
+		}
+		return s
+	}
+	s := name + " " + strconv.Itoa(first)
+	if last != first {
+		s += "-" + strconv.Itoa(last)
+	}
+	return s
+}
+
+func (ev pirkeiAvotEvent) GetFlags() HolidayFlags {
+	return PIRKEI_AVOT
+}
+
+func (ev pirkeiAvotEvent) GetEmoji() string {
+	return ""
+}
+
+func (ev pirkeiAvotEvent) Basename() string {
+	return ev.Render("en")
+}
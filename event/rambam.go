@@ -0,0 +1,46 @@
+package event
+
+import (
+	"strconv"
+
+	"github.com/hebcal/gematriya"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+	"github.com/MaxBGreenberg/hebcal-go/rambam"
+)
+
+type rambamYomiEvent struct {
+	Date    hdate.HDate
+	Reading rambam.Reading
+}
+
+func NewRambamYomiEvent(hd hdate.HDate, reading rambam.Reading) CalEvent {
+	return rambamYomiEvent{Date: hd, Reading: reading}
+}
+
+func (ev rambamYomiEvent) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+func (ev rambamYomiEvent) Render(locale string) string {
+	name := func(s string) string {
+		str, _ := locales.LookupTranslation(s, locale)
+		return str
+	}
+	if locale == "he" {
+		return ev.Reading.Format(name, gematriya.Gematriya, "־")
+	}
+	return ev.Reading.Format(name, strconv.Itoa, "-")
+}
+
+func (ev rambamYomiEvent) GetFlags() HolidayFlags {
+	return RAMBAM_YOMI
+}
+
+func (ev rambamYomiEvent) GetEmoji() string {
+	return ""
+}
+
+func (ev rambamYomiEvent) Basename() string {
+	return ev.Reading.String()
+}
@@ -0,0 +1,44 @@
+package event
+
+import (
+	"strconv"
+
+	"github.com/hebcal/gematriya"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/dafyomi"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+)
+
+type tanakh929Event struct {
+	Date    hdate.HDate
+	Chapter dafyomi.Daf
+}
+
+func NewTanakh929Event(hd hdate.HDate, chapter dafyomi.Daf) CalEvent {
+	return tanakh929Event{Date: hd, Chapter: chapter}
+}
+
+func (ev tanakh929Event) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+// Render returns "929: Genesis 1"
+func (ev tanakh929Event) Render(locale string) string {
+	name, _ := locales.LookupTranslation(ev.Chapter.Name, locale)
+	if locale == "he" {
+		return "929: " + name + " " + gematriya.Gematriya(ev.Chapter.Blatt)
+	}
+	return "929: " + name + " " + strconv.Itoa(ev.Chapter.Blatt)
+}
+
+func (ev tanakh929Event) GetFlags() HolidayFlags {
+	return TANAKH_929
+}
+
+func (ev tanakh929Event) GetEmoji() string {
+	return ""
+}
+
+func (ev tanakh929Event) Basename() string {
+	return ev.Chapter.String()
+}
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"strconv"
+
+	"github.com/hebcal/gematriya"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+	"github.com/MaxBGreenberg/hebcal-go/tehillim"
+)
+
+// Daily portion of Tehillim in the monthly or weekly cycle
+type tehillimEvent struct {
+	Date    hdate.HDate
+	Portion tehillim.Portion
+	Cycle   tehillim.Cycle
+}
+
+func NewTehillimEvent(hd hdate.HDate, portion tehillim.Portion, cycle tehillim.Cycle) CalEvent {
+	return tehillimEvent{Date: hd, Portion: portion, Cycle: cycle}
+}
+
+func (ev tehillimEvent) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+// Render returns "Tehillim 1-9" or "Tehillim 119:1-96" for the
+// monthly cycle, and "Weekly Tehillim 1-29" for the weekly cycle.
+func (ev tehillimEvent) Render(locale string) string {
+	title := "Tehillim"
+	if ev.Cycle == tehillim.Weekly {
+		title = "Weekly Tehillim"
+	}
+	name, _ := locales.LookupTranslation(title, locale)
+	p := ev.Portion
+	num := strconv.Itoa
+	sep := "-"
+	if locale == "he" {
+		num = gematriya.Gematriya
+		sep = "־"
+	}
+	s := name + " " + num(p.Begin)
+	if p.BeginVerse != 0 {
+		return s + ":" + num(p.BeginVerse) + sep + num(p.EndVerse)
+	}
+	if p.End != p.Begin {
+		s += sep + num(p.End)
+	}
+	return s
+}
+
+func (ev tehillimEvent) GetFlags() HolidayFlags {
+	return TEHILLIM_YOMI
+}
+
+func (ev tehillimEvent) GetEmoji() string {
+	return ""
+}
+
+func (ev tehillimEvent) Basename() string {
+	return ev.Portion.String()
+}
@@ -0,0 +1,58 @@
+package event
+
+import (
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+)
+
+// The Torah reading on a Monday or Thursday morning or on Shabbat
+// afternoon (Mincha), taken from the beginning of the upcoming parsha.
+type weekdayReadingEvent struct {
+	Date   hdate.HDate
+	Parsha string   // upcoming parsha, e.g. "Bereshit"
+	Torah  string   // the entire reading, e.g. "Genesis 1:1-13"
+	Aliyot []string // the three aliyot, e.g. "Genesis 1:1-5"
+}
+
+func NewWeekdayReadingEvent(hd hdate.HDate, parsha string, torah string, aliyot []string) CalEvent {
+	return weekdayReadingEvent{Date: hd, Parsha: parsha, Torah: torah, Aliyot: aliyot}
+}
+
+// WeekdayReading returns the entire reading and the three aliyot of an
+// event created by NewWeekdayReadingEvent. ok is false for any other event.
+func WeekdayReading(ev CalEvent) (torah string, aliyot []string, ok bool) {
+	if w, isWeekday := ev.(weekdayReadingEvent); isWeekday {
+		return w.Torah, w.Aliyot, true
+	}
+	return "", nil, false
+}
+
+func (ev weekdayReadingEvent) GetDate() hdate.HDate {
+	return ev.Date
+}
+
+// Render returns "Torah reading: Bereshit", or on Saturday,
+// "Shabbat Mincha: Bereshit".
+func (ev weekdayReadingEvent) Render(locale string) string {
+	key := "Torah reading"
+	if ev.Date.Weekday() == time.Saturday {
+		key = "Shabbat Mincha"
+	}
+	prefix, _ := locales.LookupTranslation(key, locale)
+	name, _ := locales.LookupTranslation(ev.Parsha, locale)
+	return prefix + ": " + name
+}
+
+func (ev weekdayReadingEvent) GetFlags() HolidayFlags {
+	return WEEKDAY_READING
+}
+
+func (ev weekdayReadingEvent) GetEmoji() string {
+	return ""
+}
+
+func (ev weekdayReadingEvent) Basename() string {
+	return ev.Parsha
+}
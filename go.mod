@@ -9,10 +9,10 @@ require (
 	github.com/hebcal/hdate v1.0.2
 	github.com/nathan-osman/go-sunrise v1.1.0
 	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
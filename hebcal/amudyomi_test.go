@@ -0,0 +1,40 @@
+package hebcal_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmudYomiAndDafWeekly(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:      hdate.FromGregorian(2023, time.October, 20),
+		End:        hdate.FromGregorian(2023, time.October, 23),
+		NoHolidays: true,
+		AmudYomi:   true,
+		DafWeekly:  true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-10-20,Berachot 4a",
+		"2023-10-21,Berachot 4b",
+		"2023-10-22,Daf HaShavua: Ketubot 59",
+		"2023-10-22,Berachot 5a",
+		"2023-10-23,Berachot 5b",
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "ברכות דף ד׳ ע״ב", events[1].Render("he"))
+	assert.Equal(t, "דַּף הַשָּׁבוּעַ: כתובות דף נ״ט", events[2].Render("he"))
+	assert.Equal(t, "amudyomi", hebcal.NewJSONItem(events[0], "en").Category)
+	assert.Equal(t, "dafweekly", hebcal.NewJSONItem(events[2], "en").Category)
+}
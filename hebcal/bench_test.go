@@ -0,0 +1,47 @@
+package hebcal_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+)
+
+func benchmarkHebrewCalendar(b *testing.B, numYears int) {
+	yahrzeits := make([]hebcal.UserYahrzeit, 50)
+	for i := range yahrzeits {
+		yahrzeits[i] = hebcal.UserYahrzeit{
+			Date: time.Date(1950+i, time.Month(i%12+1), i%28+1, 0, 0, 0, 0, time.UTC),
+			Name: "Yahrzeit " + strconv.Itoa(i),
+		}
+	}
+	opts := hebcal.CalOptions{
+		Year:           2000,
+		NumYears:       numYears,
+		CandleLighting: true,
+		Location:       zmanim.LookupCity("Boston"),
+		Sedrot:         true,
+		Yahrzeits:      yahrzeits,
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := hebcal.HebrewCalendar(&opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHebrewCalendar1Year(b *testing.B) {
+	benchmarkHebrewCalendar(b, 1)
+}
+
+func BenchmarkHebrewCalendar10Years(b *testing.B) {
+	benchmarkHebrewCalendar(b, 10)
+}
+
+func BenchmarkHebrewCalendar100Years(b *testing.B) {
+	benchmarkHebrewCalendar(b, 100)
+}
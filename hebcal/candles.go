@@ -78,13 +78,20 @@ func (ev TimedEvent) GetDate() hdate.HDate {
 }
 
 func (ev TimedEvent) Render(locale string) string {
+	desc := ev.RenderBrief(locale)
+	timeStr := formatTime(&ev.EventTime, ev.opts)
+	return fmt.Sprintf("%s: %s", desc, timeStr)
+}
+
+// RenderBrief returns the translated description of this event
+// without the time of day (e.g. "Havdalah (50 min)").
+func (ev TimedEvent) RenderBrief(locale string) string {
 	desc, _ := locales.LookupTranslation(ev.Desc, locale)
 	if ev.Desc == "Havdalah" && ev.sunsetOffset != 0 {
 		minStr, _ := locales.LookupTranslation("min", locale)
 		desc = fmt.Sprintf("%s (%d %s)", desc, ev.sunsetOffset, minStr)
 	}
-	timeStr := formatTime(&ev.EventTime, ev.opts)
-	return fmt.Sprintf("%s: %s", desc, timeStr)
+	return desc
 }
 
 func (ev TimedEvent) GetFlags() event.HolidayFlags {
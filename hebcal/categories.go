@@ -0,0 +1,86 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import "github.com/MaxBGreenberg/hebcal-go/event"
+
+// getCategories returns a category (and for holidays, a subcategory)
+// for an event, using the same vocabulary as hebcal.com.
+// For example, {"holiday", "major"}, {"candles"} or {"parashat"}.
+func getCategories(ev event.CalEvent) []string {
+	ev, _ = unwrapScheduleEvent(ev, "")
+	if timed, ok := ev.(TimedEvent); ok {
+		switch timed.Desc {
+		case "Candle lighting":
+			return []string{"candles"}
+		case "Havdalah":
+			return []string{"havdalah"}
+		case "Fast begins", "Fast ends":
+			return []string{"zmanim"}
+		}
+	}
+	mask := ev.GetFlags()
+	switch {
+	case (mask & event.DAF_YOMI) != 0:
+		return []string{"dafyomi"}
+	case (mask & event.AMUD_YOMI) != 0:
+		return []string{"amudyomi"}
+	case (mask & event.DAF_WEEKLY) != 0:
+		return []string{"dafweekly"}
+	case (mask & event.MISHNA_YOMI) != 0:
+		return []string{"mishnayomi"}
+	case (mask & event.NACH_YOMI) != 0:
+		return []string{"nachyomi"}
+	case (mask & event.RAMBAM_YOMI) != 0:
+		return []string{"rambam"}
+	case (mask & event.TANAKH_929) != 0:
+		return []string{"929"}
+	case (mask & event.TEHILLIM_YOMI) != 0:
+		return []string{"tehillim"}
+	case (mask & event.YERUSHALMI_YOMI) != 0:
+		return []string{"yerushalmi"}
+	case (mask & event.OMER_COUNT) != 0:
+		return []string{"omer"}
+	case (mask & event.PARSHA_HASHAVUA) != 0:
+		return []string{"parashat"}
+	case (mask & event.WEEKDAY_READING) != 0:
+		return []string{"leyning"}
+	case (mask & event.PIRKEI_AVOT) != 0:
+		return []string{"pirkeiavot"}
+	case (mask & event.HEBREW_DATE) != 0:
+		return []string{"hebdate"}
+	case (mask & event.MOLAD) != 0:
+		return []string{"molad"}
+	case (mask & event.USER_EVENT) != 0:
+		return []string{"user"}
+	case (mask & event.ZMANIM) != 0:
+		return []string{"zmanim"}
+	case (mask & event.SHABBAT_MEVARCHIM) != 0:
+		return []string{"mevarchim"}
+	case (mask & event.ROSH_CHODESH) != 0:
+		return []string{"roshchodesh"}
+	case (mask & event.MODERN_HOLIDAY) != 0:
+		return []string{"holiday", "modern"}
+	case (mask&(event.MAJOR_FAST|event.MINOR_FAST)) != 0 && (mask&event.CHAG) == 0:
+		return []string{"holiday", "fast"}
+	case (mask & event.SPECIAL_SHABBAT) != 0:
+		return []string{"holiday", "shabbat"}
+	case (mask & event.MINOR_HOLIDAY) != 0:
+		return []string{"holiday", "minor"}
+	}
+	return []string{"holiday", "major"}
+}
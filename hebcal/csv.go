@@ -0,0 +1,66 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/MaxBGreenberg/hebcal-go/event"
+)
+
+var csvHeader = []string{
+	"Subject", "Start Date", "Start Time", "End Date", "End Time",
+	"All day event", "Description", "Show time as", "Location",
+}
+
+// WriteCSV writes events to w in the comma-separated format
+// accepted by Outlook's calendar import.
+func WriteCSV(w io.Writer, events []event.CalEvent, opts *CalOptions, locale string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	var locationName string
+	if opts != nil && opts.Location != nil {
+		locationName = opts.Location.Name
+	}
+	for _, ev := range events {
+		ev, marker := unwrapScheduleEvent(ev, locale)
+		year, month, day := ev.GetDate().Greg()
+		dateStr := strconv.Itoa(int(month)) + "/" + strconv.Itoa(day) + "/" + strconv.Itoa(year)
+		record := []string{"", dateStr, "", "", "", "true", "", "3", ""}
+		if timed, ok := ev.(TimedEvent); ok {
+			record[0] = timed.RenderBrief(locale)
+			record[2] = timed.EventTime.Format("3:04 PM")
+			record[5] = "false"
+			record[8] = locationName
+			if timed.LinkedEvent != nil {
+				record[6] = timed.LinkedEvent.Render(locale)
+			}
+		} else {
+			record[0] = ev.Render(locale)
+		}
+		record[0] += marker
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,30 @@
+package hebcal_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCSV(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:          hdate.New(5782, hdate.Elul, 27),
+		End:            hdate.New(5782, hdate.Elul, 28),
+		CandleLighting: true,
+		Location:       zmanim.LookupCity("Chicago"),
+		Sedrot:         true,
+	}
+	events, _ := hebcal.HebrewCalendar(&opts)
+	var buf bytes.Buffer
+	err := hebcal.WriteCSV(&buf, events, &opts, "en")
+	assert.Equal(t, nil, err)
+	expected := "Subject,Start Date,Start Time,End Date,End Time,All day event,Description,Show time as,Location\n" +
+		"Candle lighting,9/23/2022,6:28 PM,,,false,,3,Chicago\n" +
+		"Parashat Nitzavim,9/24/2022,,,,true,,3,\n" +
+		"Havdalah,9/24/2022,7:26 PM,,,false,,3,Chicago\n"
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,276 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"context"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+)
+
+// ParshaDivergence is a Shabbat on which Israel and the Diaspora
+// read different Torah portions.
+type ParshaDivergence struct {
+	Date     hdate.HDate
+	Israel   sedra.Parsha
+	Diaspora sedra.Parsha
+}
+
+// DivergencePeriod is a run of consecutive Shabbatot on which the
+// Israel and Diaspora readings differ.
+type DivergencePeriod struct {
+	Weeks []ParshaDivergence
+	// First Shabbat after the period on which both read the same portion
+	Realigned hdate.HDate
+}
+
+// HolidayDivergence lists the holidays on Date that appear in
+// only one of the Israel and Diaspora schedules.
+type HolidayDivergence struct {
+	Date     hdate.HDate
+	Israel   []event.HolidayEvent // only in Israel
+	Diaspora []event.HolidayEvent // only in the Diaspora
+}
+
+// DivergenceReport is the result of Divergence.
+type DivergenceReport struct {
+	Year      int
+	Parshiyot []DivergencePeriod
+	Holidays  []HolidayDivergence
+}
+
+func sameParsha(a, b sedra.Parsha) bool {
+	if a.Chag != b.Chag || len(a.Name) != len(b.Name) {
+		return false
+	}
+	for i := range a.Name {
+		if a.Name[i] != b.Name[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Divergence compares the Israel and Diaspora schedules for a Hebrew year.
+
+It reports each period of Shabbatot on which the two read different
+Torah portions, which happens when the eighth day of Pesach or the second
+day of Shavuot falls on Shabbat: Israel moves on to the next parsha while
+the Diaspora reads for the holiday, and Israel stays a week ahead until
+the Diaspora doubles up a pair of parshiyot that Israel reads separately.
+
+It also reports each day whose holidays differ between the schedules,
+such as the second day of Yom Tov observed only in the Diaspora.
+*/
+func Divergence(year int) DivergenceReport {
+	report := DivergenceReport{
+		Year:      year,
+		Parshiyot: make([]DivergencePeriod, 0, 1),
+		Holidays:  make([]HolidayDivergence, 0),
+	}
+	startAbs := hdate.ToRD(year, hdate.Tishrei, 1)
+	endAbs := hdate.ToRD(year+1, hdate.Tishrei, 1)
+	var period *DivergencePeriod
+	for abs := hdate.DayOnOrBefore(time.Saturday, startAbs+6); abs < endAbs || period != nil; abs += 7 {
+		hd := hdate.FromRD(abs)
+		sedraIL := sedra.New(hd.Year(), true)
+		sedraChul := sedra.New(hd.Year(), false)
+		il := sedraIL.LookupByRD(abs)
+		chul := sedraChul.LookupByRD(abs)
+		if !sameParsha(il, chul) {
+			if period == nil {
+				period = &DivergencePeriod{Weeks: make([]ParshaDivergence, 0, 8)}
+			}
+			period.Weeks = append(period.Weeks, ParshaDivergence{Date: hd, Israel: il, Diaspora: chul})
+		} else if period != nil {
+			period.Realigned = hd
+			report.Parshiyot = append(report.Parshiyot, *period)
+			period = nil
+		}
+	}
+
+	israel := getHolidayYear(year, true).byDay
+	diaspora := getHolidayYear(year, false).byDay
+	for abs := startAbs; abs < endAbs; abs++ {
+		onlyIL := holidaysNotIn(israel[abs], diaspora[abs])
+		onlyChul := holidaysNotIn(diaspora[abs], israel[abs])
+		if len(onlyIL) != 0 || len(onlyChul) != 0 {
+			report.Holidays = append(report.Holidays, HolidayDivergence{
+				Date:     hdate.FromRD(abs),
+				Israel:   onlyIL,
+				Diaspora: onlyChul,
+			})
+		}
+	}
+	return report
+}
+
+// holidaysNotIn returns the events in a whose description
+// doesn't appear in b.
+func holidaysNotIn(a, b []event.HolidayEvent) []event.HolidayEvent {
+	result := make([]event.HolidayEvent, 0)
+	for _, ev := range a {
+		found := false
+		for _, other := range b {
+			if ev.Desc == other.Desc {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, ev)
+		}
+	}
+	return result
+}
+
+// ScheduleEvent marks an event that occurs in only one of the Israel
+// and Diaspora schedules when CalOptions.BothSchedules is set.
+type ScheduleEvent struct {
+	event.CalEvent
+	IL bool // true if only in Israel, false if only in the Diaspora
+}
+
+// marker returns " (Israel)" or " (Diaspora)", translated to locale.
+func (ev ScheduleEvent) marker(locale string) string {
+	where := "Diaspora"
+	if ev.IL {
+		where = "Israel"
+	}
+	s, _ := locales.LookupTranslation(where, locale)
+	return " (" + s + ")"
+}
+
+// Render returns the title of the wrapped event followed by
+// " (Israel)" or " (Diaspora)".
+func (ev ScheduleEvent) Render(locale string) string {
+	return ev.CalEvent.Render(locale) + ev.marker(locale)
+}
+
+// unwrapScheduleEvent returns the event wrapped by a ScheduleEvent
+// and the marker to append to its title, or ev itself and "".
+func unwrapScheduleEvent(ev event.CalEvent, locale string) (event.CalEvent, string) {
+	if s, ok := ev.(ScheduleEvent); ok {
+		return s.CalEvent, s.marker(locale)
+	}
+	return ev, ""
+}
+
+// scheduleKey identifies equivalent events on the same day in the two
+// schedules.
+type scheduleKey struct {
+	desc  string
+	flags event.HolidayFlags
+	t     int64 // Unix time of a TimedEvent
+}
+
+// scheduleFlags are the flags that differ between the Israel and
+// Diaspora schedules for holidays both observe, such as Pesach I,
+// which ends with Havdalah in Israel and candle-lighting elsewhere.
+const scheduleFlags = event.IL_ONLY | event.CHUL_ONLY | maskLightCandles
+
+func newScheduleKey(ev event.CalEvent) scheduleKey {
+	key := scheduleKey{desc: ev.Basename(), flags: ev.GetFlags() &^ scheduleFlags}
+	switch e := ev.(type) {
+	case event.HolidayEvent:
+		key.desc = e.Desc
+	case TimedEvent:
+		// flags come from the day, e.g. CHAG for candle-lighting on
+		// the second day of Yom Tov, so match on the time instead
+		key.desc = e.Desc
+		key.flags = 0
+		key.t = e.EventTime.Unix()
+	}
+	return key
+}
+
+// newScheduleGen returns a generator for the Israel (il=true) or
+// Diaspora schedule of opts. The schedule is chosen by il alone,
+// even if opts.Location is in Israel.
+func newScheduleGen(opts *CalOptions, il bool) (*calendarGen, error) {
+	o := *opts
+	o.BothSchedules = false
+	resolved, err := resolveOptions(&o)
+	if err != nil {
+		return nil, err
+	}
+	resolved.IL = il
+	return newCalendarGen(resolved)
+}
+
+// bothSchedules generates the Diaspora and Israel calendars for opts
+// together, one day at a time. Events common to both are passed to fn
+// once; the others are wrapped in a ScheduleEvent, Diaspora events first.
+func bothSchedules(ctx context.Context, opts *CalOptions, fn func(event.CalEvent) error) error {
+	diaspora, err := newScheduleGen(opts, false)
+	if err != nil {
+		return err
+	}
+	israel, err := newScheduleGen(opts, true)
+	if err != nil {
+		return err
+	}
+	dayChul := make([]event.CalEvent, 0, 20)
+	dayIL := make([]event.CalEvent, 0, 20)
+	for abs := diaspora.startAbs; abs <= diaspora.endAbs; abs++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		dayChul = diaspora.day(abs, dayChul[:0])
+		dayIL = israel.day(abs, dayIL[:0])
+		if err := mergeScheduleDay(dayChul, dayIL, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeScheduleDay passes the events of one day to fn, wrapping those
+// found in only one of the schedules in a ScheduleEvent.
+func mergeScheduleDay(diaspora, israel []event.CalEvent, fn func(event.CalEvent) error) error {
+	used := make([]bool, len(israel))
+	for _, ev := range diaspora {
+		key := newScheduleKey(ev)
+		found := false
+		for k, other := range israel {
+			if !used[k] && newScheduleKey(other) == key {
+				used[k] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			ev = ScheduleEvent{CalEvent: ev, IL: false}
+		}
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+	for k, ev := range israel {
+		if !used[k] {
+			if err := fn(ScheduleEvent{CalEvent: ev, IL: true}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
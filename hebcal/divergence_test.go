@@ -0,0 +1,157 @@
+package hebcal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivergence5782(t *testing.T) {
+	report := hebcal.Divergence(5782)
+	assert.Equal(t, 5782, report.Year)
+	assert.Equal(t, 1, len(report.Parshiyot))
+	period := report.Parshiyot[0]
+	first := period.Weeks[0]
+	assert.Equal(t, hdate.ToRD(5782, hdate.Nisan, 22), first.Date.Abs())
+	assert.Equal(t, []string{"Achrei Mot"}, first.Israel.Name)
+	assert.Equal(t, true, first.Diaspora.Chag)
+	last := period.Weeks[len(period.Weeks)-1]
+	assert.Equal(t, hdate.ToRD(5782, hdate.Av, 2), last.Date.Abs())
+	assert.Equal(t, []string{"Masei"}, last.Israel.Name)
+	assert.Equal(t, []string{"Matot", "Masei"}, last.Diaspora.Name)
+	assert.Equal(t, hdate.ToRD(5782, hdate.Av, 9), period.Realigned.Abs())
+}
+
+func TestDivergence5783(t *testing.T) {
+	report := hebcal.Divergence(5783)
+	assert.Equal(t, 1, len(report.Parshiyot))
+	period := report.Parshiyot[0]
+	assert.Equal(t, 6, len(period.Weeks))
+	assert.Equal(t, hdate.ToRD(5783, hdate.Sivan, 7), period.Weeks[0].Date.Abs())
+	assert.Equal(t, []string{"Nasso"}, period.Weeks[0].Israel.Name)
+	assert.Equal(t, []string{"Chukat", "Balak"}, period.Weeks[5].Diaspora.Name)
+	assert.Equal(t, hdate.ToRD(5783, hdate.Tamuz, 19), period.Realigned.Abs())
+}
+
+func TestDivergenceNoParshiyot(t *testing.T) {
+	report := hebcal.Divergence(5784)
+	assert.Equal(t, 0, len(report.Parshiyot))
+	assert.NotEqual(t, 0, len(report.Holidays))
+}
+
+func TestDivergenceHolidays(t *testing.T) {
+	report := hebcal.Divergence(5782)
+	pesach8 := hdate.ToRD(5782, hdate.Nisan, 22)
+	found := false
+	for _, day := range report.Holidays {
+		if day.Date.Abs() == pesach8 {
+			found = true
+			assert.Equal(t, 0, len(day.Israel))
+			assert.Equal(t, 1, len(day.Diaspora))
+			assert.Equal(t, "Pesach VIII", day.Diaspora[0].Desc)
+		}
+	}
+	assert.Equal(t, true, found)
+}
+
+func TestBothSchedules(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:         hdate.New(5783, hdate.Nisan, 14),
+		End:           hdate.New(5783, hdate.Nisan, 23),
+		Sedrot:        true,
+		NoModern:      true,
+		BothSchedules: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-04-05,Erev Pesach",
+		"2023-04-05,Ta'anit Bechorot",
+		"2023-04-06,Pesach I",
+		"2023-04-07,Pesach II (Diaspora)",
+		"2023-04-07,Pesach II (CH''M) (Israel)",
+		"2023-04-08,Pesach III (CH''M)",
+		"2023-04-09,Pesach IV (CH''M)",
+		"2023-04-10,Pesach V (CH''M)",
+		"2023-04-11,Pesach VI (CH''M)",
+		"2023-04-12,Pesach VII",
+		"2023-04-13,Pesach VIII (Diaspora)",
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "פֶּסַח ב׳ (חוּץ לָאָרֶץ)", events[3].Render("he"))
+}
+
+// A location in Israel doesn't switch the Diaspora schedule to Israel
+func TestBothSchedulesIsraelLocation(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:          hdate.New(5783, hdate.Nisan, 14),
+		End:            hdate.New(5783, hdate.Nisan, 22),
+		CandleLighting: true,
+		Location:       zmanim.LookupCity("Jerusalem"),
+		NoModern:       true,
+		BothSchedules:  true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-04-05,Erev Pesach",
+		"2023-04-05,Fast begins: 5:09",
+		"2023-04-05,Ta'anit Bechorot",
+		"2023-04-05,Candle lighting: 6:20",
+		"2023-04-06,Pesach I",
+		"2023-04-06,Candle lighting: 7:37 (Diaspora)",
+		"2023-04-06,Havdalah: 7:37 (Israel)",
+		"2023-04-07,Pesach II (Diaspora)",
+		"2023-04-07,Candle lighting: 6:21",
+		"2023-04-07,Pesach II (CH''M) (Israel)",
+		"2023-04-08,Pesach III (CH''M)",
+		"2023-04-08,Havdalah: 7:39",
+		"2023-04-09,Pesach IV (CH''M)",
+		"2023-04-10,Pesach V (CH''M)",
+		"2023-04-11,Pesach VI (CH''M)",
+		"2023-04-11,Candle lighting: 6:24",
+		"2023-04-12,Pesach VII",
+		"2023-04-12,Candle lighting: 7:42 (Diaspora)",
+		"2023-04-12,Havdalah: 7:42 (Israel)",
+		"2023-04-13,Pesach VIII (Diaspora)",
+		"2023-04-13,Havdalah: 7:42 (Diaspora)",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestBothSchedulesStopsEarly(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Year:          5783,
+		IsHebrewYear:  true,
+		Sedrot:        true,
+		BothSchedules: true,
+	}
+	stop := errors.New("stop")
+	count := 0
+	err := hebcal.HebrewCalendarFunc(context.Background(), &opts, func(ev event.CalEvent) error {
+		count++
+		if count == 3 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 3, count)
+}
@@ -18,6 +18,7 @@ package hebcal
 // along with this program. If not, see <https://www.gnu.org/licenses/>.
 
 import (
+	"context"
 	"errors"
 	"math"
 	"time"
@@ -26,11 +27,16 @@ import (
 	"github.com/hebcal/hdate"
 	"github.com/MaxBGreenberg/hebcal-go/dafyomi"
 	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/leyning"
 	"github.com/MaxBGreenberg/hebcal-go/mishnayomi"
 	"github.com/MaxBGreenberg/hebcal-go/molad"
 	"github.com/MaxBGreenberg/hebcal-go/nachyomi"
 	"github.com/MaxBGreenberg/hebcal-go/omer"
+	"github.com/MaxBGreenberg/hebcal-go/pirkeiavot"
+	"github.com/MaxBGreenberg/hebcal-go/rambam"
 	"github.com/MaxBGreenberg/hebcal-go/sedra"
+	"github.com/MaxBGreenberg/hebcal-go/tanakh929"
+	"github.com/MaxBGreenberg/hebcal-go/tehillim"
 	"github.com/MaxBGreenberg/hebcal-go/yerushalmi"
 	"github.com/MaxBGreenberg/hebcal-go/zmanim"
 )
@@ -60,15 +66,23 @@ Unless opts.NoHolidays == true, default holidays include:
   - Rosh Chodesh (unless opts.NoRoshChodesh)
 
 Holiday and Torah reading schedules differ between Israel and the Disapora.
-Set opts.IL=true to use the Israeli schedule.
+Set opts.IL=true to use the Israeli schedule, or opts.BothSchedules=true
+for both, with events that differ marked "(Israel)" or "(Diaspora)".
 
 Additional non-default event types can be specified:
-  - Parashat HaShavua - weekly Torah Reading on Saturdays (opts.Sedrot)
+  - Parashat HaShavua - weekly Torah Reading on Saturdays (opts.Sedrot),
+    optionally with the triennial cycle reading (opts.Triennial)
   - Counting of the Omer (opts.Omer)
-  - Babylonian Talmud Daf Yomi (opts.DafYomi)
+  - Babylonian Talmud Daf Yomi (opts.DafYomi), Amud Yomi (opts.AmudYomi)
+    or Daf HaShavua (opts.DafWeekly)
   - Jerusalem Talmud (Yerushalmi) Yomi (opts.YerushalmiYomi)
   - Mishna Yomi (opts.MishnaYomi)
   - Nach Yomi (opts.NachYomi)
+  - Rambam Yomi, three chapters or one chapter a day (opts.RambamYomi, opts.RambamCycle)
+  - 929, a chapter of Tanakh each day from Sunday through Thursday (opts.Tanakh929)
+  - Daily Tehillim in the monthly or weekly cycle (opts.TehillimMonthly, opts.TehillimWeekly)
+  - Monday, Thursday and Shabbat Mincha Torah readings (opts.WeekdayReadings)
+  - Pirkei Avot on Shabbat afternoons in summer (opts.PirkeiAvot)
   - Shabbat Mevarchim HaChodesh on Saturday before Rosh Chodesh (opts.ShabbatMevarchim)
   - Molad announcement on Saturday before Rosh Chodesh (opts.Molad)
   - Yom Kippur Katan (opts.YomKippurKatan)
@@ -107,161 +121,321 @@ in the evening).
 Two options also exist for generating an Event with the Hebrew date:
   - opts.AddHebrewDates - print the Hebrew date for the entire date range
   - opts.AddHebrewDatesForEvents - print the Hebrew date for dates with some events
+
+HebrewCalendar does not modify opts, so the same CalOptions may be
+reused for several calls or shared between goroutines.
 */
 func HebrewCalendar(opts *CalOptions) ([]event.CalEvent, error) {
-	err := checkCandleOptions(opts)
+	events := make([]event.CalEvent, 0, 20)
+	err := HebrewCalendarFunc(context.Background(), opts, func(ev event.CalEvent) error {
+		events = append(events, ev)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if opts.SunriseSunset && opts.Location == nil {
-		return nil, errors.New("opts.SunriseSunset requires opts.Location")
+	return events, nil
+}
+
+/*
+HebrewCalendarFunc is the streaming form of HebrewCalendar. Instead of
+returning a slice, it calls fn for each event, one day at a time and in
+the same order as HebrewCalendar, so that large multi-year calendars
+can be written out incrementally.
+
+If fn returns an error, iteration stops and HebrewCalendarFunc returns
+that error. The context is checked before each day is generated;
+if it is cancelled, HebrewCalendarFunc returns ctx.Err().
+*/
+func HebrewCalendarFunc(ctx context.Context, opts *CalOptions, fn func(event.CalEvent) error) error {
+	if opts.BothSchedules {
+		return bothSchedules(ctx, opts, fn)
 	}
-	if opts.DailyZmanim && opts.Location == nil {
-		return nil, errors.New("opts.DailyZmanim requires opts.Location")
+	opts, err := resolveOptions(opts)
+	if err != nil {
+		return err
 	}
+	return calendarFunc(ctx, opts, fn)
+}
+
+// calendarFunc generates the events of HebrewCalendarFunc for options
+// already returned by resolveOptions.
+func calendarFunc(ctx context.Context, opts *CalOptions, fn func(event.CalEvent) error) error {
+	gen, err := newCalendarGen(opts)
+	if err != nil {
+		return err
+	}
+	events := make([]event.CalEvent, 0, 20)
+	for abs := gen.startAbs; abs <= gen.endAbs; abs++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		events = gen.day(abs, events[:0])
+		for _, ev := range events {
+			if err := fn(ev); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// calendarGen generates the events of a calendar one day at a time,
+// keeping the per-year state between days.
+type calendarGen struct {
+	opts            *CalOptions
+	startAbs        int64
+	endAbs          int64
+	firstWeekday    time.Weekday
+	beginYerushalmi int64
+	currentYear     int
+	holidaysYear    *holidayYear
+	sedraYear       sedra.Sedra
+	beginOmer       int64
+	endOmer         int64
+	myIdx           mishnayomi.MishnaYomiIndex
+	nachIdx         nachyomi.NachYomiIndex
+	rambamIdx       rambam.RambamYomiIndex
+	idx929          tanakh929.Index929
+	monthlyIdx      tehillim.MonthlyIndex
+	weeklyIdx       tehillim.WeeklyIndex
+	pirkeiAvot      pirkeiavot.Schedule
+	userEvents      dayIndex
+}
+
+func newCalendarGen(opts *CalOptions) (*calendarGen, error) {
 	startAbs, endAbs, err := getStartAndEnd(opts)
 	if err != nil {
 		return nil, err
 	}
-	if opts.Location != nil && opts.Location.CountryCode == "IL" {
-		opts.IL = true
+	gen := &calendarGen{
+		opts:            opts,
+		startAbs:        startAbs,
+		endAbs:          endAbs,
+		firstWeekday:    time.Weekday(startAbs % 7),
+		beginYerushalmi: yerushalmi.VilnaStartRD,
+		currentYear:     -1,
 	}
-	opts.Mask = getMaskFromOptions(opts)
-	if opts.YerushalmiYomi && opts.YerushalmiEdition == 0 {
-		opts.YerushalmiEdition = yerushalmi.Vilna
-	}
-	beginYerushalmi := yerushalmi.VilnaStartRD
 	if opts.YerushalmiEdition == yerushalmi.Schottenstein {
-		beginYerushalmi = yerushalmi.SchottensteinStartRD
-	}
-	var (
-		il           bool = opts.IL
-		currentYear  int  = -1
-		holidaysYear []event.HolidayEvent
-		sedraYear    sedra.Sedra
-		beginOmer    int64
-		endOmer      int64
-		myIdx        mishnayomi.MishnaYomiIndex
-		nachIdx      nachyomi.NachYomiIndex
-		userEvents   []event.HolidayEvent
-	)
-	firstWeekday := time.Weekday(startAbs % 7)
-	events := make([]event.CalEvent, 0, 20)
-	for abs := startAbs; abs <= endAbs; abs++ {
-		hd := hdate.FromRD(abs)
-		hyear := hd.Year()
-		if hyear != currentYear {
-			currentYear = hyear
-			holidaysYear = GetHolidaysForYear(hyear, il)
-			if opts.Sedrot || opts.DailySedra {
-				sedraYear = sedra.New(hyear, il)
+		gen.beginYerushalmi = yerushalmi.SchottensteinStartRD
+	}
+	return gen, nil
+}
+
+// startYear sets up the state for the Hebrew year hyear.
+func (gen *calendarGen) startYear(hyear int) {
+	opts := gen.opts
+	il := opts.IL
+	gen.currentYear = hyear
+	gen.holidaysYear = getHolidayYear(hyear, il)
+	if opts.Sedrot || opts.DailySedra {
+		gen.sedraYear = sedra.New(hyear, il)
+	}
+	if opts.PirkeiAvot {
+		gen.pirkeiAvot = pirkeiavot.New(hyear, gen.holidaysYear.events)
+	}
+	if opts.Omer {
+		gen.beginOmer = hdate.ToRD(hyear, hdate.Nisan, 16)
+		gen.endOmer = hdate.ToRD(hyear, hdate.Sivan, 5)
+	}
+	numUserEvents := len(opts.Yahrzeits) + len(opts.UserEvents)
+	if numUserEvents != 0 {
+		yearEvents := make([]event.HolidayEvent, 0, numUserEvents)
+		for _, yahrzeit := range opts.Yahrzeits {
+			origDate := hdate.FromTime(yahrzeit.Date)
+			observedDate, err := hdate.GetYahrzeit(hyear, origDate)
+			if err == nil {
+				yearEvents = append(yearEvents, event.HolidayEvent{
+					Date:  observedDate,
+					Desc:  yahrzeit.Name,
+					Flags: event.USER_EVENT,
+				})
 			}
-			if opts.Omer {
-				beginOmer = hdate.ToRD(hyear, hdate.Nisan, 16)
-				endOmer = hdate.ToRD(hyear, hdate.Sivan, 5)
+		}
+		for _, userEv := range opts.UserEvents {
+			// Watch for ShortKislev and LongCheshvan
+			if userEv.Day <= hdate.DaysInMonth(userEv.Month, hyear) {
+				yearEvents = append(yearEvents, event.HolidayEvent{
+					Date:  hdate.New(hyear, userEv.Month, userEv.Day),
+					Desc:  userEv.Desc,
+					Flags: event.USER_EVENT,
+				})
 			}
-			numUserEvents := len(opts.Yahrzeits) + len(opts.UserEvents)
-			if numUserEvents != 0 {
-				userEvents = make([]event.HolidayEvent, 0, numUserEvents)
-				for _, yahrzeit := range opts.Yahrzeits {
-					origDate := hdate.FromTime(yahrzeit.Date)
-					observedDate, err := hdate.GetYahrzeit(currentYear, origDate)
-					if err == nil {
-						userEvents = append(userEvents, event.HolidayEvent{
-							Date:  observedDate,
-							Desc:  yahrzeit.Name,
-							Flags: event.USER_EVENT,
-						})
-					}
-				}
-				for _, userEv := range opts.UserEvents {
-					// Watch for ShortKislev and LongCheshvan
-					if userEv.Day <= hdate.DaysInMonth(userEv.Month, hyear) {
-						userEvents = append(userEvents, event.HolidayEvent{
-							Date:  hdate.New(hyear, userEv.Month, userEv.Day),
-							Desc:  userEv.Desc,
-							Flags: event.USER_EVENT,
-						})
-					}
+		}
+		gen.userEvents = makeDayIndex(yearEvents)
+	}
+}
+
+// day appends the events for abs to events, in the order
+// HebrewCalendarFunc passes them to its callback.
+func (gen *calendarGen) day(abs int64, events []event.CalEvent) []event.CalEvent {
+	opts := gen.opts
+	il := opts.IL
+	firstWeekday := gen.firstWeekday
+	hd := hdate.FromRD(abs)
+	hyear := hd.Year()
+	if hyear != gen.currentYear {
+		gen.startYear(hyear)
+	}
+	dow := hd.Weekday()
+	if opts.SunriseSunset && (!opts.WeeklyAbbreviated || dow == firstWeekday) {
+		events = append(events, riseSetEvent{date: hd, opts: opts})
+	}
+	if opts.DailySedra || (opts.Sedrot && dow == time.Saturday) {
+		parsha := gen.sedraYear.LookupByRD(abs)
+		if !parsha.Chag {
+			ev := event.NewParshaEvent(hd, parsha, il)
+			if opts.Triennial {
+				if reading, err := gen.sedraYear.LookupTriennial(hd); err == nil {
+					ev = event.NewTriennialParshaEvent(hd, parsha, il, reading)
 				}
 			}
+			events = append(events, ev)
 		}
-		dow := hd.Weekday()
-		prevEventsLength := len(events)
-		if opts.SunriseSunset && (!opts.WeeklyAbbreviated || dow == firstWeekday) {
-			events = append(events, riseSetEvent{date: hd, opts: opts})
-		}
-		if opts.DailySedra || (opts.Sedrot && dow == time.Saturday) {
-			parsha := sedraYear.LookupByRD(abs)
-			if !parsha.Chag {
-				events = append(events, event.NewParshaEvent(hd, parsha, il))
+	}
+	if opts.WeekdayReadings && (dow == time.Monday || dow == time.Thursday || dow == time.Saturday) &&
+		!hasHolidayReading(gen.holidaysYear.byDay[abs], il) {
+		if reading, err := leyning.WeekdayReading(hd, il); err == nil {
+			aliyot := make([]string, len(reading.Aliyot))
+			for i, aliyah := range reading.Aliyot {
+				aliyot[i] = aliyah.String()
 			}
+			events = append(events, event.NewWeekdayReadingEvent(hd, reading.Parsha[0], reading.Torah.String(), aliyot))
 		}
-		var candlesEv TimedEvent
-		for _, holidayEv := range holidaysYear {
-			if hd == holidayEv.Date {
-				events, candlesEv = appendHolidayAndRelated(events, candlesEv, holidayEv, opts)
-			}
+	}
+	if opts.DafWeekly && dow == time.Sunday && abs >= dafyomi.DafWeeklyStart {
+		daf, _ := dafyomi.NewDafWeekly(hd)
+		events = append(events, event.NewDafWeeklyEvent(hd, daf))
+	}
+	if opts.PirkeiAvot && dow == time.Saturday {
+		if chapters := gen.pirkeiAvot.Lookup(hd); chapters != nil {
+			events = append(events, event.NewPirkeiAvotEvent(hd, chapters))
 		}
-		for _, userEv := range userEvents {
-			if abs == userEv.Date.Abs() {
-				events = append(events, userEv)
-			}
+	}
+	var candlesEv TimedEvent
+	for _, holidayEv := range gen.holidaysYear.byDay[abs] {
+		events, candlesEv = appendHolidayAndRelated(events, candlesEv, holidayEv, opts)
+	}
+	for _, userEv := range gen.userEvents[abs] {
+		events = append(events, userEv)
+	}
+	if !opts.WeeklyAbbreviated || dow == firstWeekday {
+		if opts.Omer && abs >= gen.beginOmer && abs <= gen.endOmer {
+			omerDay := int(abs - gen.beginOmer + 1)
+			events = append(events, omer.NewOmerEvent(hd, omerDay))
 		}
-		if !opts.WeeklyAbbreviated || dow == firstWeekday {
-			if opts.Omer && abs >= beginOmer && abs <= endOmer {
-				omerDay := int(abs - beginOmer + 1)
-				events = append(events, omer.NewOmerEvent(hd, omerDay))
+		if opts.DafYomi && hyear >= 5684 {
+			daf, _ := dafyomi.New(hd)
+			events = append(events, event.NewDafYomiEvent(hd, daf))
+		}
+		if opts.AmudYomi && abs >= dafyomi.AmudYomiStart {
+			amud, _ := dafyomi.NewAmudYomi(hd)
+			events = append(events, event.NewAmudYomiEvent(hd, amud))
+		}
+		if opts.YerushalmiYomi && abs >= gen.beginYerushalmi {
+			daf := yerushalmi.New(hd, opts.YerushalmiEdition)
+			// daf.Blatt will be 0 to signal no Yerushalmi Yomi on YK and 9Av
+			if daf.Blatt != 0 {
+				events = append(events, event.NewYerushalmiYomiEvent(hd, daf))
 			}
-			if opts.DafYomi && hyear >= 5684 {
-				daf, _ := dafyomi.New(hd)
-				events = append(events, event.NewDafYomiEvent(hd, daf))
+		}
+		if opts.MishnaYomi && abs >= mishnayomi.MishnaYomiStart {
+			if len(gen.myIdx) == 0 {
+				gen.myIdx = mishnayomi.MakeIndex()
 			}
-			if opts.YerushalmiYomi && abs >= beginYerushalmi {
-				daf := yerushalmi.New(hd, opts.YerushalmiEdition)
-				// daf.Blatt will be 0 to signal no Yerushalmi Yomi on YK and 9Av
-				if daf.Blatt != 0 {
-					events = append(events, event.NewYerushalmiYomiEvent(hd, daf))
-				}
+			mishna, _ := gen.myIdx.Lookup(hd)
+			events = append(events, event.NewMishnaYomiEvent(hd, mishna))
+		}
+		if opts.NachYomi && abs >= nachyomi.NachYomiStart {
+			if len(gen.nachIdx) == 0 {
+				gen.nachIdx = nachyomi.MakeIndex()
 			}
-			if opts.MishnaYomi && abs >= mishnayomi.MishnaYomiStart {
-				if len(myIdx) == 0 {
-					myIdx = mishnayomi.MakeIndex()
-				}
-				mishna, _ := myIdx.Lookup(hd)
-				events = append(events, event.NewMishnaYomiEvent(hd, mishna))
+			chapter, _ := gen.nachIdx.Lookup(hd)
+			events = append(events, event.NewNachYomiEvent(hd, chapter))
+		}
+		if opts.RambamYomi && abs >= rambam.RambamStart {
+			if len(gen.rambamIdx) == 0 {
+				gen.rambamIdx = rambam.MakeIndex()
 			}
-			if opts.NachYomi && abs >= nachyomi.NachYomiStart {
-				if len(nachIdx) == 0 {
-					nachIdx = nachyomi.MakeIndex()
-				}
-				chapter, _ := nachIdx.Lookup(hd)
-				events = append(events, event.NewNachYomiEvent(hd, chapter))
+			reading, _ := gen.rambamIdx.Lookup(hd, opts.RambamCycle)
+			events = append(events, event.NewRambamYomiEvent(hd, reading))
+		}
+		if opts.Tanakh929 && abs >= tanakh929.Start929 {
+			if len(gen.idx929) == 0 {
+				gen.idx929 = tanakh929.MakeIndex()
 			}
-			if opts.DailyZmanim {
-				zmanEvents := dailyZemanim(hd, opts)
-				events = append(events, zmanEvents...)
+			// no chapter on Friday, Shabbat or Yom Tov
+			if chapter, err := gen.idx929.Lookup(hd); err == nil {
+				events = append(events, event.NewTanakh929Event(hd, chapter))
 			}
 		}
-		if (candlesEv == TimedEvent{}) && opts.CandleLighting && (dow == time.Friday || dow == time.Saturday) {
-			candlesEv = makeCandleEvent(hd, opts, nil)
-		}
-		if (candlesEv != TimedEvent{}) {
-			events = append(events, candlesEv)
+		if opts.TehillimMonthly {
+			if len(gen.monthlyIdx) == 0 {
+				gen.monthlyIdx = tehillim.MakeMonthlyIndex()
+			}
+			portion := gen.monthlyIdx.Lookup(hd)
+			events = append(events, event.NewTehillimEvent(hd, portion, tehillim.Monthly))
 		}
-		if opts.Molad && dow == time.Saturday && hd.Month() != hdate.Elul && hd.Day() >= 23 && hd.Day() <= 29 {
-			nextMonthName, nextMonth := nextMonthName(hd.Year(), hd.Month())
-			molad := molad.New(hd.Year(), nextMonth)
-			events = append(events, event.NewMoladEvent(hd, molad, nextMonthName))
+		if opts.TehillimWeekly {
+			if len(gen.weeklyIdx) == 0 {
+				gen.weeklyIdx = tehillim.MakeWeeklyIndex()
+			}
+			portion := gen.weeklyIdx.Lookup(hd)
+			events = append(events, event.NewTehillimEvent(hd, portion, tehillim.Weekly))
 		}
-		if (opts.AddHebrewDates && (!opts.WeeklyAbbreviated || dow == firstWeekday)) ||
-			((opts.AddHebrewDates || opts.AddHebrewDatesForEvents) && prevEventsLength != len(events)) {
-			events = append(events, nil)
-			copy(events[prevEventsLength+1:], events[prevEventsLength:])
-			events[prevEventsLength] = event.NewHebrewDateEvent(hd)
+		if opts.DailyZmanim {
+			zmanEvents := dailyZemanim(hd, opts)
+			events = append(events, zmanEvents...)
 		}
 	}
-	return events, nil
+	if (candlesEv == TimedEvent{}) && opts.CandleLighting && (dow == time.Friday || dow == time.Saturday) {
+		candlesEv = makeCandleEvent(hd, opts, nil)
+	}
+	if (candlesEv != TimedEvent{}) {
+		events = append(events, candlesEv)
+	}
+	if opts.Molad && dow == time.Saturday && hd.Month() != hdate.Elul && hd.Day() >= 23 && hd.Day() <= 29 {
+		nextMonthName, nextMonth := nextMonthName(hd.Year(), hd.Month())
+		molad := molad.New(hd.Year(), nextMonth)
+		events = append(events, event.NewMoladEvent(hd, molad, nextMonthName))
+	}
+	if (opts.AddHebrewDates && (!opts.WeeklyAbbreviated || dow == firstWeekday)) ||
+		((opts.AddHebrewDates || opts.AddHebrewDatesForEvents) && len(events) != 0) {
+		events = append(events, nil)
+		copy(events[1:], events)
+		events[0] = event.NewHebrewDateEvent(hd)
+	}
+	return events
+}
+
+// resolveOptions validates opts and returns a copy with defaults filled
+// in and Mask computed. The caller's CalOptions is never modified, so a
+// single CalOptions may be reused or shared between goroutines.
+func resolveOptions(opts *CalOptions) (*CalOptions, error) {
+	resolved := *opts
+	opts = &resolved
+	err := checkCandleOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SunriseSunset && opts.Location == nil {
+		return nil, errors.New("opts.SunriseSunset requires opts.Location")
+	}
+	if opts.DailyZmanim && opts.Location == nil {
+		return nil, errors.New("opts.DailyZmanim requires opts.Location")
+	}
+	// disable candle-lighting times for very early dates
+	if (opts.Start == hdate.HDate{}) && !opts.IsHebrewYear && opts.Year != 0 && opts.Year < 100 {
+		opts.CandleLighting = false
+	}
+	if opts.Location != nil && opts.Location.CountryCode == "IL" {
+		opts.IL = true
+	}
+	opts.Mask = getMaskFromOptions(opts)
+	if opts.YerushalmiYomi && opts.YerushalmiEdition == 0 {
+		opts.YerushalmiEdition = yerushalmi.Vilna
+	}
+	return opts, nil
 }
 
 func getStartAndEnd(opts *CalOptions) (int64, int64, error) {
@@ -301,10 +475,6 @@ func getStartAndEnd(opts *CalOptions) (int64, int64, error) {
 		endAbs := endDate.Abs() - 1
 		return startAbs, endAbs, nil
 	} else {
-		// disable candle-lighting times for very early dates
-		if year < 100 {
-			opts.CandleLighting = false
-		}
 		month := time.January
 		if opts.Month != 0 {
 			month = opts.Month
@@ -400,6 +570,12 @@ func getMaskFromOptions(opts *CalOptions) event.HolidayFlags {
 		if (m & event.DAF_YOMI) != 0 {
 			opts.DafYomi = true
 		}
+		if (m & event.AMUD_YOMI) != 0 {
+			opts.AmudYomi = true
+		}
+		if (m & event.DAF_WEEKLY) != 0 {
+			opts.DafWeekly = true
+		}
 		if (m & event.OMER_COUNT) != 0 {
 			opts.Omer = true
 		}
@@ -412,6 +588,21 @@ func getMaskFromOptions(opts *CalOptions) event.HolidayFlags {
 		if (m & event.NACH_YOMI) != 0 {
 			opts.NachYomi = true
 		}
+		if (m & event.RAMBAM_YOMI) != 0 {
+			opts.RambamYomi = true
+		}
+		if (m & event.TANAKH_929) != 0 {
+			opts.Tanakh929 = true
+		}
+		if (m&event.TEHILLIM_YOMI) != 0 && !opts.TehillimWeekly {
+			opts.TehillimMonthly = true
+		}
+		if (m & event.WEEKDAY_READING) != 0 {
+			opts.WeekdayReadings = true
+		}
+		if (m & event.PIRKEI_AVOT) != 0 {
+			opts.PirkeiAvot = true
+		}
 		if (m & event.YOM_KIPPUR_KATAN) != 0 {
 			opts.YomKippurKatan = true
 		}
@@ -457,6 +648,12 @@ func getMaskFromOptions(opts *CalOptions) event.HolidayFlags {
 	if opts.DafYomi {
 		mask |= event.DAF_YOMI
 	}
+	if opts.AmudYomi {
+		mask |= event.AMUD_YOMI
+	}
+	if opts.DafWeekly {
+		mask |= event.DAF_WEEKLY
+	}
 	if opts.MishnaYomi {
 		mask |= event.MISHNA_YOMI
 	}
@@ -466,6 +663,21 @@ func getMaskFromOptions(opts *CalOptions) event.HolidayFlags {
 	if opts.NachYomi {
 		mask |= event.NACH_YOMI
 	}
+	if opts.RambamYomi {
+		mask |= event.RAMBAM_YOMI
+	}
+	if opts.Tanakh929 {
+		mask |= event.TANAKH_929
+	}
+	if opts.TehillimMonthly || opts.TehillimWeekly {
+		mask |= event.TEHILLIM_YOMI
+	}
+	if opts.WeekdayReadings {
+		mask |= event.WEEKDAY_READING
+	}
+	if opts.PirkeiAvot {
+		mask |= event.PIRKEI_AVOT
+	}
 	if opts.Omer {
 		mask |= event.OMER_COUNT
 	}
@@ -478,6 +690,22 @@ func getMaskFromOptions(opts *CalOptions) event.HolidayFlags {
 	return mask
 }
 
+// hasHolidayReading reports whether a holiday Torah reading among
+// holidays replaces the weekday reading. On Shabbat, only a holiday
+// Mincha reading (Yom Kippur) replaces it.
+func hasHolidayReading(holidays []event.HolidayEvent, il bool) bool {
+	for _, ev := range holidays {
+		reading, err := leyning.LookupHoliday(ev, il, leyning.Ashkenazi)
+		if err != nil {
+			continue
+		}
+		if ev.Date.Weekday() != time.Saturday || reading.Mincha != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func appendHolidayAndRelated(events []event.CalEvent, candlesEv TimedEvent, ev event.CalEvent, opts *CalOptions) ([]event.CalEvent, TimedEvent) {
 	mask := ev.GetFlags()
 	if (!opts.YomKippurKatan && (mask&event.YOM_KIPPUR_KATAN) != 0) ||
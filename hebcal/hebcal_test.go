@@ -1,6 +1,8 @@
 package hebcal_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -560,3 +562,133 @@ func TestHebrewCalendarZmanimOnly(t *testing.T) {
 	assert.Equal(nil, err)
 	assert.Equal(15, len(events)) // not 16 (no Alot HaShachar)
 }
+
+func TestHebrewCalendarFunc(t *testing.T) {
+	newOpts := func() *hebcal.CalOptions {
+		return &hebcal.CalOptions{
+			Year:           2022,
+			Sedrot:         true,
+			CandleLighting: true,
+			Location:       zmanim.LookupCity("Boston"),
+			DafYomi:        true,
+			AddHebrewDates: true,
+		}
+	}
+	expected, err := hebcal.HebrewCalendar(newOpts())
+	assert.Equal(t, nil, err)
+	actual := make([]event.CalEvent, 0, len(expected))
+	err = hebcal.HebrewCalendarFunc(context.Background(), newOpts(), func(ev event.CalEvent) error {
+		actual = append(actual, ev)
+		return nil
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		assert.Equal(t, expected[i].GetDate(), actual[i].GetDate())
+		assert.Equal(t, expected[i].Render("en"), actual[i].Render("en"))
+	}
+}
+
+func TestHebrewCalendarFuncStop(t *testing.T) {
+	errStop := errors.New("stop")
+	count := 0
+	opts := hebcal.CalOptions{Year: 2022, AddHebrewDates: true}
+	err := hebcal.HebrewCalendarFunc(context.Background(), &opts, func(ev event.CalEvent) error {
+		count++
+		if count == 10 {
+			return errStop
+		}
+		return nil
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, 10, count)
+}
+
+func TestHebrewCalendarFuncCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var dates []string
+	opts := hebcal.CalOptions{Year: 2022, NumYears: 50, AddHebrewDates: true, NoHolidays: true}
+	err := hebcal.HebrewCalendarFunc(ctx, &opts, func(ev event.CalEvent) error {
+		dates = append(dates, hd2iso(ev.GetDate()))
+		if len(dates) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{"2022-01-01", "2022-01-02", "2022-01-03"}, dates)
+
+	err = hebcal.HebrewCalendarFunc(ctx, &opts, func(ev event.CalEvent) error {
+		t.Error("callback invoked after cancellation")
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func renderAll(events []event.CalEvent) []string {
+	result := make([]string, len(events))
+	for i, ev := range events {
+		result[i] = hd2iso(ev.GetDate()) + " " + ev.Render("en")
+	}
+	return result
+}
+
+func TestHebrewCalendarDoesNotModifyOptions(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Year:           50,
+		CandleLighting: true,
+		Location:       zmanim.LookupCity("Jerusalem"),
+		Mask:           event.YERUSHALMI_YOMI | event.PARSHA_HASHAVUA,
+	}
+	orig := opts
+	_, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, orig, opts)
+
+	opts = hebcal.CalOptions{
+		Year:           2022,
+		Month:          time.April,
+		CandleLighting: true,
+		Location:       zmanim.LookupCity("Jerusalem"),
+		YerushalmiYomi: true,
+		Sedrot:         true,
+	}
+	orig = opts
+	first, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, orig, opts)
+	for i := 0; i < 3; i++ {
+		again, err := hebcal.HebrewCalendar(&opts)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, renderAll(first), renderAll(again))
+	}
+	assert.Equal(t, orig, opts)
+}
+
+func TestHebrewCalendarParallel(t *testing.T) {
+	opts := &hebcal.CalOptions{
+		Year:           2022,
+		CandleLighting: true,
+		Location:       zmanim.LookupCity("Boston"),
+		HavdalahMins:   50,
+		Sedrot:         true,
+		DafYomi:        true,
+		Omer:           true,
+	}
+	expected, err := hebcal.HebrewCalendar(opts)
+	assert.Equal(t, nil, err)
+	want := renderAll(expected)
+	const n = 8
+	results := make(chan []string, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			events, _ := hebcal.HebrewCalendar(opts)
+			results <- renderAll(events)
+		}()
+	}
+	for i := 0; i < n; i++ {
+		assert.Equal(t, want, <-results)
+	}
+	assert.Equal(t, 50, opts.HavdalahMins)
+	assert.Equal(t, event.HolidayFlags(0), opts.Mask)
+}
@@ -0,0 +1,86 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"sync"
+
+	"github.com/MaxBGreenberg/hebcal-go/event"
+)
+
+// dayIndex maps an R.D. day number to the events on that day,
+// in their original order.
+type dayIndex map[int64][]event.HolidayEvent
+
+func makeDayIndex(events []event.HolidayEvent) dayIndex {
+	idx := make(dayIndex, len(events))
+	for i := range events {
+		abs := events[i].Date.Abs()
+		idx[abs] = append(idx[abs], events[i])
+	}
+	return idx
+}
+
+// holidayYear is the cached, read-only set of holidays for one
+// Hebrew year and holiday schedule.
+type holidayYear struct {
+	events []event.HolidayEvent
+	byDay  dayIndex
+}
+
+type holidayYearKey struct {
+	year int
+	il   bool
+}
+
+// maxCachedYears bounds memory use; the cache is simply cleared
+// when it fills up.
+const maxCachedYears = 512
+
+var holidayCache = struct {
+	sync.RWMutex
+	m map[holidayYearKey]*holidayYear
+}{m: make(map[holidayYearKey]*holidayYear)}
+
+// getHolidayYear returns the holidays for a Hebrew year, computing
+// them at most once per year and schedule. The result is shared
+// between callers and must not be modified.
+func getHolidayYear(year int, il bool) *holidayYear {
+	key := holidayYearKey{year, il}
+	holidayCache.RLock()
+	hy := holidayCache.m[key]
+	holidayCache.RUnlock()
+	if hy != nil {
+		return hy
+	}
+	all := getAllHolidaysForYear(year)
+	events := make([]event.HolidayEvent, 0, len(all))
+	for _, ev := range all {
+		if (il && (ev.Flags&event.CHUL_ONLY) == 0) ||
+			(!il && (ev.Flags&event.IL_ONLY) == 0) {
+			events = append(events, ev)
+		}
+	}
+	hy = &holidayYear{events: events, byDay: makeDayIndex(events)}
+	holidayCache.Lock()
+	if len(holidayCache.m) >= maxCachedYears {
+		holidayCache.m = make(map[holidayYearKey]*holidayYear)
+	}
+	holidayCache.m[key] = hy
+	holidayCache.Unlock()
+	return hy
+}
@@ -622,14 +622,12 @@ func getBirkatHaChama(year int) int64 {
 
 // Returns a slice of holidays for the year.
 // For Israel holiday schedule, specify il=true.
+//
+// Results are cached per year; the returned slice is a copy that
+// the caller may modify.
 func GetHolidaysForYear(year int, il bool) []event.HolidayEvent {
-	events := getAllHolidaysForYear(year)
-	result := make([]event.HolidayEvent, 0, len(events))
-	for _, ev := range events {
-		if (il && (ev.Flags&event.CHUL_ONLY) == 0) ||
-			(!il && (ev.Flags&event.IL_ONLY) == 0) {
-			result = append(result, ev)
-		}
-	}
+	events := getHolidayYear(year, il).events
+	result := make([]event.HolidayEvent, len(events))
+	copy(result, events)
 	return result
 }
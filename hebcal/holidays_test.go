@@ -461,3 +461,12 @@ func TestHolidaysEarlyYears(t *testing.T) {
 	events = hebcal.GetHolidaysForYear(1, false)
 	assert.Equal(t, 99, len(events))
 }
+
+func TestGetHolidaysForYearCached(t *testing.T) {
+	events := hebcal.GetHolidaysForYear(5783, true)
+	first := events[0]
+	events[0].Desc = "Modified"
+	events = hebcal.GetHolidaysForYear(5783, true)
+	assert.Equal(t, first, events[0])
+	assert.Equal(t, 110, len(hebcal.GetHolidaysForYear(5771, false)))
+}
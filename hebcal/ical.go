@@ -0,0 +1,347 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MaxBGreenberg/hebcal-go/event"
+)
+
+// ICalOptions are used by WriteICalendar() to configure the
+// generated VCALENDAR.
+type ICalOptions struct {
+	// Locale passed to Render() for each SUMMARY (default "en")
+	Locale string
+	// Calendar name (X-WR-CALNAME), e.g. "Hebcal Diaspora 2022"
+	Title string
+	// Calendar description (X-WR-CALDESC)
+	Description string
+	// Timestamp used for every DTSTAMP (default time.Now())
+	DTStamp time.Time
+}
+
+const icalProdID = "-//hebcal-go//NONSGML Hebcal Calendar v1.0//EN"
+
+// WriteICalendar writes events as an RFC 5545 VCALENDAR to w.
+//
+// Timed events (candle-lighting, Havdalah, fast begins/ends, zmanim)
+// become VEVENTs with a DTSTART in the time zone of opts.Location, and
+// a matching VTIMEZONE component is emitted. Without opts.Location,
+// times in UTC are written as UTC, and times in any other named time
+// zone get a VTIMEZONE of their own; an error is returned for times in
+// time.Local, which has no time zone identifier. All other events
+// become all-day VEVENTs.
+//
+// The UID of each VEVENT is derived from the event date and description,
+// so regenerating the calendar yields the same UIDs.
+func WriteICalendar(w io.Writer, events []event.CalEvent, opts *CalOptions, icalOpts ICalOptions) error {
+	locale := icalOpts.Locale
+	if locale == "" {
+		locale = "en"
+	}
+	dtstamp := icalOpts.DTStamp
+	if dtstamp.IsZero() {
+		dtstamp = time.Now()
+	}
+	stampStr := dtstamp.UTC().Format("20060102T150405Z")
+	var tzid string
+	if opts != nil && opts.Location != nil {
+		tzid = opts.Location.TimeZoneId
+	}
+	iw := &icalWriter{w: bufio.NewWriter(w)}
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:" + icalProdID)
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("METHOD:PUBLISH")
+	if icalOpts.Title != "" {
+		iw.line("X-WR-CALNAME:" + icalEscape(icalOpts.Title))
+	}
+	if icalOpts.Description != "" {
+		iw.line("X-WR-CALDESC:" + icalEscape(icalOpts.Description))
+	}
+	if tzid != "" {
+		iw.line("X-WR-TIMEZONE:" + tzid)
+		if hasTimedEvents(events) {
+			if err := writeVTimezone(iw, tzid, events); err != nil {
+				return err
+			}
+		}
+	} else {
+		zones, err := eventTimeZones(events)
+		if err != nil {
+			return err
+		}
+		for _, zone := range zones {
+			if err := writeVTimezone(iw, zone, events); err != nil {
+				return err
+			}
+		}
+	}
+	uids := make(map[string]int, len(events))
+	for _, ev := range events {
+		writeVEvent(iw, ev, opts, locale, stampStr, uids)
+	}
+	iw.line("END:VCALENDAR")
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+// eventTimeZones returns the names of the time zones, other than UTC,
+// of the timed events, in order of first use.
+func eventTimeZones(events []event.CalEvent) ([]string, error) {
+	var zones []string
+	seen := make(map[string]bool)
+	for _, ev := range events {
+		ev, _ = unwrapScheduleEvent(ev, "")
+		timed, ok := ev.(TimedEvent)
+		if !ok {
+			continue
+		}
+		zone := timed.EventTime.Location().String()
+		if zone == "Local" {
+			return nil, errors.New("timed event in the Local time zone; set opts.Location")
+		}
+		if zone != "UTC" && !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func hasTimedEvents(events []event.CalEvent) bool {
+	for _, ev := range events {
+		ev, _ = unwrapScheduleEvent(ev, "")
+		if _, ok := ev.(TimedEvent); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func writeVEvent(iw *icalWriter, ev event.CalEvent, opts *CalOptions, locale string, stampStr string, uids map[string]int) {
+	ev, marker := unwrapScheduleEvent(ev, locale)
+	hd := ev.GetDate()
+	year, month, day := hd.Greg()
+	dateStr := fmt.Sprintf("%04d%02d%02d", year, month, day)
+	var summary string
+	timed, isTimed := ev.(TimedEvent)
+	if isTimed {
+		summary = timed.RenderBrief(locale)
+	} else {
+		summary = ev.Render(locale)
+	}
+	summary += marker
+	uid := makeUID(dateStr, ev, uids)
+	iw.line("BEGIN:VEVENT")
+	iw.line("DTSTAMP:" + stampStr)
+	iw.line("UID:" + uid)
+	iw.line("SUMMARY:" + icalEscape(summary))
+	if isTimed {
+		t := timed.EventTime
+		tzid := t.Location().String()
+		if opts != nil && opts.Location != nil && opts.Location.TimeZoneId != "" {
+			tzid = opts.Location.TimeZoneId
+		}
+		if tzid == "UTC" {
+			timeStr := t.Format("20060102T150405Z")
+			iw.line("DTSTART:" + timeStr)
+			iw.line("DTEND:" + timeStr)
+		} else {
+			timeStr := t.Format("20060102T150405")
+			iw.line("DTSTART;TZID=" + tzid + ":" + timeStr)
+			iw.line("DTEND;TZID=" + tzid + ":" + timeStr)
+		}
+	} else {
+		next := hd.Next()
+		nyear, nmonth, nday := next.Greg()
+		iw.line("DTSTART;VALUE=DATE:" + dateStr)
+		iw.line(fmt.Sprintf("DTEND;VALUE=DATE:%04d%02d%02d", nyear, nmonth, nday))
+	}
+	iw.line("TRANSP:TRANSPARENT")
+	iw.line("X-MICROSOFT-CDO-BUSYSTATUS:FREE")
+	categories := getCategories(ev)
+	for i, category := range categories {
+		categories[i] = icalEscape(category)
+	}
+	iw.line("CATEGORIES:" + strings.Join(categories, ","))
+	if isTimed && opts != nil && opts.Location != nil && opts.Location.Name != "" {
+		iw.line("LOCATION:" + icalEscape(opts.Location.Name))
+	}
+	iw.line("END:VEVENT")
+}
+
+// makeUID returns a stable UID for the event. Events with identical
+// dates and descriptions get a numeric suffix to keep UIDs unique.
+func makeUID(dateStr string, ev event.CalEvent, uids map[string]int) string {
+	h := fnv.New64a()
+	io.WriteString(h, ev.Render("en"))
+	io.WriteString(h, "\x00")
+	io.WriteString(h, ev.Basename())
+	uid := fmt.Sprintf("hebcal-%s-%016x", dateStr, h.Sum64())
+	seen := uids[uid]
+	uids[uid] = seen + 1
+	if seen != 0 {
+		uid = fmt.Sprintf("%s-%d", uid, seen)
+	}
+	return uid
+}
+
+// writeVTimezone emits a VTIMEZONE covering the years spanned by events.
+func writeVTimezone(iw *icalWriter, tzid string, events []event.CalEvent) error {
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return err
+	}
+	startYear, endYear := 0, 0
+	for _, ev := range events {
+		year, _, _ := ev.GetDate().Greg()
+		if startYear == 0 || year < startYear {
+			startYear = year
+		}
+		if year > endYear {
+			endYear = year
+		}
+	}
+	iw.line("BEGIN:VTIMEZONE")
+	iw.line("TZID:" + tzid)
+	transitions := findTransitions(loc, startYear-1, endYear+1)
+	if len(transitions) == 0 {
+		name, offset := time.Date(startYear, time.January, 1, 0, 0, 0, 0, loc).Zone()
+		iw.line("BEGIN:STANDARD")
+		iw.line("DTSTART:19700101T000000")
+		iw.line("TZOFFSETFROM:" + formatOffset(offset))
+		iw.line("TZOFFSETTO:" + formatOffset(offset))
+		iw.line("TZNAME:" + name)
+		iw.line("END:STANDARD")
+	}
+	for _, tr := range transitions {
+		component := "STANDARD"
+		if tr.offsetTo > tr.offsetFrom {
+			component = "DAYLIGHT"
+		}
+		// DTSTART is expressed in local time prior to the transition
+		local := tr.at.UTC().Add(time.Duration(tr.offsetFrom) * time.Second)
+		iw.line("BEGIN:" + component)
+		iw.line("DTSTART:" + local.Format("20060102T150405"))
+		iw.line("TZOFFSETFROM:" + formatOffset(tr.offsetFrom))
+		iw.line("TZOFFSETTO:" + formatOffset(tr.offsetTo))
+		iw.line("TZNAME:" + tr.name)
+		iw.line("END:" + component)
+	}
+	iw.line("END:VTIMEZONE")
+	return nil
+}
+
+type tzTransition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+}
+
+// findTransitions returns UTC offset changes for loc between
+// January 1 of startYear and December 31 of endYear.
+func findTransitions(loc *time.Location, startYear, endYear int) []tzTransition {
+	var result []tzTransition
+	t := time.Date(startYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(endYear+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	_, prevOffset := t.In(loc).Zone()
+	for t.Before(end) {
+		next := t.Add(24 * time.Hour)
+		_, offset := next.In(loc).Zone()
+		if offset != prevOffset {
+			// binary search for the exact second of the transition
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, o := mid.In(loc).Zone(); o == prevOffset {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			name, _ := hi.In(loc).Zone()
+			result = append(result, tzTransition{at: hi, offsetFrom: prevOffset, offsetTo: offset, name: name})
+			prevOffset = offset
+		}
+		t = next
+	}
+	return result
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+var icalEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// icalEscape escapes a TEXT property value per RFC 5545 section 3.3.11.
+func icalEscape(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+type icalWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line terminated by CRLF, folding lines longer
+// than 75 octets without splitting a UTF-8 sequence.
+func (iw *icalWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+	var sb strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		sb.WriteString(s[:cut])
+		sb.WriteString("\r\n ")
+		s = s[cut:]
+		// continuation lines begin with a space, which counts toward the limit
+		limit = 74
+	}
+	sb.WriteString(s)
+	sb.WriteString("\r\n")
+	_, iw.err = iw.w.WriteString(sb.String())
+}
@@ -0,0 +1,117 @@
+package hebcal_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteICalendar(t *testing.T) {
+	assert := assert.New(t)
+	loc := zmanim.LookupCity("Chicago")
+	opts := hebcal.CalOptions{
+		Start:          hdate.New(5782, hdate.Elul, 25),
+		End:            hdate.New(5783, hdate.Tishrei, 8),
+		CandleLighting: true,
+		Location:       loc,
+		HavdalahMins:   50,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(nil, err)
+	var buf bytes.Buffer
+	err = hebcal.WriteICalendar(&buf, events, &opts, hebcal.ICalOptions{
+		Title:   "Hebcal Chicago",
+		DTStamp: time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(nil, err)
+	ical := buf.String()
+	assert.True(strings.HasPrefix(ical, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(strings.HasSuffix(ical, "END:VCALENDAR\r\n"))
+	assert.Equal(len(events), strings.Count(ical, "BEGIN:VEVENT\r\n"))
+	assert.Equal(1, strings.Count(ical, "BEGIN:VTIMEZONE\r\n"))
+	assert.Contains(ical, "TZID:America/Chicago\r\n")
+	assert.Contains(ical, "BEGIN:DAYLIGHT\r\nDTSTART:20220313T020000\r\n"+
+		"TZOFFSETFROM:-0600\r\nTZOFFSETTO:-0500\r\nTZNAME:CDT\r\nEND:DAYLIGHT\r\n")
+	assert.Contains(ical, "SUMMARY:Candle lighting\r\n"+
+		"DTSTART;TZID=America/Chicago:20220923T182800\r\n")
+	assert.Contains(ical, "SUMMARY:Havdalah (50 min)\r\n")
+	assert.Contains(ical, "SUMMARY:Rosh Hashana 5783\r\n"+
+		"DTSTART;VALUE=DATE:20220926\r\nDTEND;VALUE=DATE:20220927\r\n")
+	assert.Contains(ical, "CATEGORIES:holiday,major\r\n")
+	assert.Contains(ical, "CATEGORIES:holiday,fast\r\n")
+	assert.Contains(ical, "CATEGORIES:candles\r\n")
+	assert.Contains(ical, "DTSTAMP:20220901T120000Z\r\n")
+
+	// UIDs are stable across invocations
+	var buf2 bytes.Buffer
+	events2, _ := hebcal.HebrewCalendar(&opts)
+	hebcal.WriteICalendar(&buf2, events2, &opts, hebcal.ICalOptions{
+		Title:   "Hebcal Chicago",
+		DTStamp: time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(ical, buf2.String())
+}
+
+func TestWriteICalendarLocale(t *testing.T) {
+	hd := hdate.New(5783, hdate.Nisan, 15)
+	events := []event.CalEvent{
+		event.HolidayEvent{Date: hd, Desc: "Pesach I", Flags: event.CHAG},
+	}
+	var buf bytes.Buffer
+	err := hebcal.WriteICalendar(&buf, events, &hebcal.CalOptions{}, hebcal.ICalOptions{Locale: "he"})
+	assert.Equal(t, nil, err)
+	assert.Contains(t, buf.String(), "SUMMARY:פֶּסַח א׳\r\n")
+	assert.NotContains(t, buf.String(), "VTIMEZONE")
+}
+
+func TestWriteICalendarFoldAndEscape(t *testing.T) {
+	assert := assert.New(t)
+	hd := hdate.New(5783, hdate.Nisan, 15)
+	desc := "Semicolon; comma, backslash \\ and a very long description that exceeds seventy-five octets"
+	events := []event.CalEvent{
+		event.HolidayEvent{Date: hd, Desc: desc, Flags: event.USER_EVENT},
+	}
+	var buf bytes.Buffer
+	err := hebcal.WriteICalendar(&buf, events, &hebcal.CalOptions{}, hebcal.ICalOptions{})
+	assert.Equal(nil, err)
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		assert.LessOrEqual(len(line), 75)
+	}
+	unfolded := strings.Replace(buf.String(), "\r\n ", "", -1)
+	assert.Contains(unfolded, "SUMMARY:Semicolon\\; comma\\, backslash \\\\ and a very long description")
+	assert.Contains(unfolded, "CATEGORIES:user\r\n")
+}
+
+func TestWriteICalendarNoLocation(t *testing.T) {
+	assert := assert.New(t)
+	hd := hdate.New(5783, hdate.Nisan, 14)
+	opts := hebcal.CalOptions{}
+	utc := hebcal.NewTimedEvent(hd, "Candle lighting", event.LIGHT_CANDLES,
+		time.Date(2023, time.April, 5, 17, 12, 0, 0, time.UTC), 0, nil, &opts)
+	var buf bytes.Buffer
+	err := hebcal.WriteICalendar(&buf, []event.CalEvent{utc}, &opts, hebcal.ICalOptions{})
+	assert.Equal(nil, err)
+	assert.Contains(buf.String(), "DTSTART:20230405T171200Z\r\nDTEND:20230405T171200Z\r\n")
+	assert.NotContains(buf.String(), "TZID")
+
+	jerusalem, _ := time.LoadLocation("Asia/Jerusalem")
+	il := hebcal.NewTimedEvent(hd, "Candle lighting", event.LIGHT_CANDLES,
+		time.Date(2023, time.April, 5, 18, 29, 0, 0, jerusalem), 0, nil, &opts)
+	buf.Reset()
+	err = hebcal.WriteICalendar(&buf, []event.CalEvent{il}, &opts, hebcal.ICalOptions{})
+	assert.Equal(nil, err)
+	assert.Contains(buf.String(), "BEGIN:VTIMEZONE\r\nTZID:Asia/Jerusalem\r\n")
+	assert.Contains(buf.String(), "DTSTART;TZID=Asia/Jerusalem:20230405T182900\r\n")
+
+	local := hebcal.NewTimedEvent(hd, "Candle lighting", event.LIGHT_CANDLES,
+		time.Date(2023, time.April, 5, 18, 29, 0, 0, time.Local), 0, nil, &opts)
+	err = hebcal.WriteICalendar(&buf, []event.CalEvent{local}, nil, hebcal.ICalOptions{})
+	assert.Error(err)
+}
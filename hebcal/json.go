@@ -0,0 +1,280 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/greg"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/leyning"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+)
+
+// JSONLocation describes the location used for candle-lighting times.
+type JSONLocation struct {
+	Title       string  `json:"title"`
+	City        string  `json:"city,omitempty"`
+	TimeZoneId  string  `json:"tzid"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	CountryCode string  `json:"cc,omitempty"`
+}
+
+// JSONRange is the inclusive date range covered by a JSONDocument.
+type JSONRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// JSONItem is a single event in hebcal.com REST API format.
+type JSONItem struct {
+	Title     string            `json:"title"`
+	Date      string            `json:"date"`
+	HDate     string            `json:"hdate"`
+	Category  string            `json:"category"`
+	Subcat    string            `json:"subcat,omitempty"`
+	TitleOrig string            `json:"title_orig,omitempty"`
+	Hebrew    string            `json:"hebrew"`
+	Memo      string            `json:"memo,omitempty"`
+	Link      string            `json:"link,omitempty"`
+	YomTov    bool              `json:"yomtov,omitempty"`
+	Leyning   map[string]string `json:"leyning,omitempty"`
+	Triennial map[string]string `json:"triennial,omitempty"`
+}
+
+// JSONDocument is the top-level hebcal.com REST API response.
+type JSONDocument struct {
+	Title    string        `json:"title"`
+	Date     string        `json:"date"`
+	Location *JSONLocation `json:"location,omitempty"`
+	Range    *JSONRange    `json:"range,omitempty"`
+	Items    []JSONItem    `json:"items"`
+}
+
+// NewJSONDocument converts events returned by HebrewCalendar() into
+// the same document shape as the hebcal.com REST API.
+//
+// Event titles are rendered in the given locale; the Hebrew title
+// is always provided via Render("he").
+func NewJSONDocument(events []event.CalEvent, opts *CalOptions, locale string) JSONDocument {
+	doc := JSONDocument{
+		Title: jsonTitle(opts),
+		Date:  time.Now().Format(time.RFC3339),
+		Items: make([]JSONItem, 0, len(events)),
+	}
+	if opts.Location != nil {
+		loc := opts.Location
+		doc.Location = &JSONLocation{
+			Title:       loc.Name,
+			City:        loc.Name,
+			TimeZoneId:  loc.TimeZoneId,
+			Latitude:    loc.Latitude,
+			Longitude:   loc.Longitude,
+			CountryCode: loc.CountryCode,
+		}
+	}
+	if startAbs, endAbs, err := getStartAndEnd(opts); err == nil {
+		doc.Range = &JSONRange{
+			Start: isoDateFromRD(startAbs),
+			End:   isoDateFromRD(endAbs),
+		}
+	}
+	for _, ev := range events {
+		doc.Items = append(doc.Items, newJSONItem(ev, locale, opts.IL))
+	}
+	return doc
+}
+
+// WriteJSON writes events to w in hebcal.com REST API format.
+func WriteJSON(w io.Writer, events []event.CalEvent, opts *CalOptions, locale string) error {
+	doc := NewJSONDocument(events, opts, locale)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(doc)
+}
+
+// NewJSONItem converts a single event to hebcal.com REST API format.
+//
+// Holiday readings follow the Diaspora schedule unless ev occurs only
+// in Israel; NewJSONDocument uses opts.IL instead.
+func NewJSONItem(ev event.CalEvent, locale string) JSONItem {
+	il := (ev.GetFlags() & event.IL_ONLY) != 0
+	return newJSONItem(ev, locale, il)
+}
+
+func newJSONItem(ev event.CalEvent, locale string, il bool) JSONItem {
+	if s, ok := ev.(ScheduleEvent); ok {
+		il = s.IL
+	}
+	_, hebrewMarker := unwrapScheduleEvent(ev, "he")
+	ev, marker := unwrapScheduleEvent(ev, locale)
+	categories := getCategories(ev)
+	hd := ev.GetDate()
+	item := JSONItem{
+		Title:    ev.Render(locale),
+		HDate:    hd.String(),
+		Category: categories[0],
+	}
+	if len(categories) > 1 {
+		item.Subcat = categories[1]
+	}
+	if timed, ok := ev.(TimedEvent); ok {
+		item.Date = timed.EventTime.Format(time.RFC3339)
+		item.TitleOrig = timed.RenderBrief("en")
+		item.Hebrew = timed.RenderBrief("he")
+		if timed.LinkedEvent != nil {
+			item.Memo = timed.LinkedEvent.Render(locale)
+		}
+	} else {
+		item.Date = isoDateFromRD(hd.Abs())
+		item.Hebrew = ev.Render("he")
+	}
+	flags := ev.GetFlags()
+	if item.Category == "holiday" && (flags&event.CHAG) != 0 {
+		item.YomTov = true
+	}
+	item.Link = eventLink(ev, categories[0])
+	if torah, aliyot, ok := event.WeekdayReading(ev); ok {
+		item.Leyning = map[string]string{"torah": torah}
+		for i, aliyah := range aliyot {
+			item.Leyning[strconv.Itoa(i+1)] = aliyah
+		}
+	}
+	if holiday, ok := ev.(event.HolidayEvent); ok {
+		item.Leyning = jsonHolidayLeyning(holiday, il)
+	}
+	if item.Category == "parashat" && hd.Weekday() == time.Saturday {
+		item.Leyning = jsonLeyning(ev.Basename())
+		if reading, ok := event.Triennial(ev); ok {
+			item.Triennial = jsonTriennial(reading)
+		}
+	}
+	item.Title += marker
+	item.Hebrew += hebrewMarker
+	return item
+}
+
+// jsonLeyning returns the aliyot of the parsha named name
+// in hebcal.com REST API format, or nil if it isn't known.
+func jsonLeyning(name string) map[string]string {
+	reading, err := leyning.ParshaReadingByName(name)
+	if err != nil {
+		return nil
+	}
+	m := map[string]string{"torah": reading.Torah.String()}
+	for i, aliyah := range reading.Aliyot {
+		m[strconv.Itoa(i+1)] = aliyah.String()
+	}
+	if reading.Maftir.Verses != 0 {
+		m["maftir"] = reading.Maftir.String()
+	}
+	return m
+}
+
+// jsonHolidayLeyning returns the Torah reading and haftarah for a
+// holiday in the same format as jsonLeyning, or nil if it has none.
+// When the weekly parsha is also read, only the aliyot added at the
+// end of it are given, numbered as the last of the seven.
+func jsonHolidayLeyning(ev event.HolidayEvent, il bool) map[string]string {
+	reading, err := leyning.LookupHoliday(ev, il, leyning.Ashkenazi)
+	if err != nil {
+		return nil
+	}
+	m := map[string]string{}
+	first := 1
+	if reading.Parsha {
+		first = 8 - len(reading.Aliyot)
+	}
+	for i, aliyah := range reading.Aliyot {
+		m[strconv.Itoa(first+i)] = aliyah.String()
+	}
+	if reading.Maftir.Verses != 0 {
+		m["maftir"] = reading.Maftir.String()
+	}
+	if len(reading.Haftarah) != 0 {
+		m["haftarah"] = reading.Haftarah.String()
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// jsonTriennial returns the triennial aliyot and maftir in the same
+// format as jsonLeyning, with "year" giving the year of the cycle.
+func jsonTriennial(reading sedra.TriennialReading) map[string]string {
+	m := map[string]string{"year": strconv.Itoa(reading.Year)}
+	for i, aliyah := range reading.Aliyot {
+		m[strconv.Itoa(i+1)] = aliyah
+	}
+	if reading.Maftir != "" {
+		m["maftir"] = reading.Maftir
+	}
+	return m
+}
+
+func jsonTitle(opts *CalOptions) string {
+	where := "Diaspora"
+	if opts.Location != nil && opts.Location.Name != "" {
+		where = opts.Location.Name
+	} else if opts.IL {
+		where = "Israel"
+	}
+	title := "Hebcal " + where
+	if opts.Year != 0 {
+		title += " " + strconv.Itoa(opts.Year)
+	}
+	return title
+}
+
+func isoDateFromRD(abs int64) string {
+	year, month, day := greg.FromRD(abs)
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+}
+
+var slugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// makeSlug turns a holiday or parsha name into a URL path segment,
+// e.g. "Tish'a B'Av" => "tisha-bav".
+func makeSlug(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer("'", "", "’", "", "‘", "").Replace(s)
+	s = slugRegexp.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
+
+// eventLink returns a hebcal.com URL for holidays and Torah readings.
+func eventLink(ev event.CalEvent, category string) string {
+	year, month, day := ev.GetDate().Greg()
+	switch category {
+	case "holiday", "roshchodesh":
+		if _, ok := ev.(event.HolidayEvent); !ok {
+			return ""
+		}
+		return "https://www.hebcal.com/holidays/" + makeSlug(ev.Basename()) + "-" + strconv.Itoa(year)
+	case "parashat":
+		dt := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return "https://www.hebcal.com/sedrot/" + makeSlug(ev.Basename()) + "-" + dt.Format("20060102")
+	}
+	return ""
+}
@@ -0,0 +1,167 @@
+package hebcal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSONDocument(t *testing.T) {
+	assert := assert.New(t)
+	loc := zmanim.LookupCity("Chicago")
+	opts := hebcal.CalOptions{
+		Start:          hdate.New(5782, hdate.Elul, 25),
+		End:            hdate.New(5783, hdate.Tishrei, 10),
+		CandleLighting: true,
+		Location:       loc,
+		HavdalahMins:   50,
+		Sedrot:         true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(nil, err)
+	doc := hebcal.NewJSONDocument(events, &opts, "en")
+	assert.Equal("Hebcal Chicago", doc.Title)
+	assert.Equal("America/Chicago", doc.Location.TimeZoneId)
+	assert.Equal(&hebcal.JSONRange{Start: "2022-09-21", End: "2022-10-05"}, doc.Range)
+	assert.Equal(len(events), len(doc.Items))
+	assert.Equal(hebcal.JSONItem{
+		Title:     "Candle lighting: 6:28",
+		Date:      "2022-09-23T18:28:00-05:00",
+		HDate:     "27 Elul 5782",
+		Category:  "candles",
+		TitleOrig: "Candle lighting",
+		Hebrew:    "הַדְלָקַת נֵרוֹת",
+	}, doc.Items[0])
+	assert.Equal(hebcal.JSONItem{
+		Title:    "Parashat Nitzavim",
+		Date:     "2022-09-24",
+		HDate:    "28 Elul 5782",
+		Category: "parashat",
+		Hebrew:   "פָּרָשַׁת נִצָּבִים",
+		Link:     "https://www.hebcal.com/sedrot/nitzavim-20220924",
+		Leyning: map[string]string{
+			"torah":  "Deuteronomy 29:9-30:20",
+			"1":      "Deuteronomy 29:9-11",
+			"2":      "Deuteronomy 29:12-14",
+			"3":      "Deuteronomy 29:15-28",
+			"4":      "Deuteronomy 30:1-6",
+			"5":      "Deuteronomy 30:7-10",
+			"6":      "Deuteronomy 30:11-14",
+			"7":      "Deuteronomy 30:15-20",
+			"maftir": "Deuteronomy 30:15-20",
+		},
+	}, doc.Items[1])
+	var yk hebcal.JSONItem
+	for _, item := range doc.Items {
+		if item.Title == "Yom Kippur" {
+			yk = item
+		}
+	}
+	assert.Equal(hebcal.JSONItem{
+		Title:    "Yom Kippur",
+		Date:     "2022-10-05",
+		HDate:    "10 Tishrei 5783",
+		Category: "holiday",
+		Subcat:   "major",
+		Hebrew:   "יוֹם כִּפּוּר",
+		Link:     "https://www.hebcal.com/holidays/yom-kippur-2022",
+		YomTov:   true,
+		Leyning: map[string]string{
+			"1":        "Leviticus 16:1-6",
+			"2":        "Leviticus 16:7-11",
+			"3":        "Leviticus 16:12-17",
+			"4":        "Leviticus 16:18-24",
+			"5":        "Leviticus 16:25-30",
+			"6":        "Leviticus 16:31-34",
+			"maftir":   "Numbers 29:7-11",
+			"haftarah": "Isaiah 57:14-58:14",
+		},
+	}, yk)
+}
+
+func TestNewJSONItemTriennial(t *testing.T) {
+	assert := assert.New(t)
+	opts := hebcal.CalOptions{
+		Start:     hdate.New(5783, hdate.Tishrei, 27),
+		End:       hdate.New(5783, hdate.Tishrei, 27),
+		Sedrot:    true,
+		Triennial: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(nil, err)
+	assert.Equal(1, len(events))
+	item := hebcal.NewJSONItem(events[0], "en")
+	assert.Equal("Parashat Bereshit", item.Title)
+	assert.Equal(map[string]string{
+		"year":   "1",
+		"1":      "Genesis 1:1-5",
+		"2":      "Genesis 1:6-8",
+		"3":      "Genesis 1:9-13",
+		"4":      "Genesis 1:14-19",
+		"5":      "Genesis 1:20-23",
+		"6":      "Genesis 1:24-31",
+		"7":      "Genesis 2:1-3",
+		"maftir": "Genesis 2:1-3",
+	}, item.Triennial)
+
+	opts.Triennial = false
+	events, _ = hebcal.HebrewCalendar(&opts)
+	item = hebcal.NewJSONItem(events[0], "en")
+	assert.Nil(item.Triennial)
+}
+
+func TestWriteJSON(t *testing.T) {
+	assert := assert.New(t)
+	opts := hebcal.CalOptions{
+		Start: hdate.New(5783, hdate.Av, 9),
+		End:   hdate.New(5783, hdate.Av, 9),
+	}
+	events, _ := hebcal.HebrewCalendar(&opts)
+	var buf bytes.Buffer
+	err := hebcal.WriteJSON(&buf, events, &opts, "en")
+	assert.Equal(nil, err)
+	var result map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &result)
+	assert.Equal(nil, err)
+	items := result["items"].([]interface{})
+	assert.Equal(1, len(items))
+	item := items[0].(map[string]interface{})
+	assert.Equal("Tish'a B'Av", item["title"])
+	assert.Equal("holiday", item["category"])
+	assert.Equal("fast", item["subcat"])
+	assert.Equal("https://www.hebcal.com/holidays/tisha-bav-2023", item["link"])
+	assert.Nil(item["yomtov"])
+}
+
+func TestNewJSONItemHolidayLeyning(t *testing.T) {
+	assert := assert.New(t)
+	opts := hebcal.CalOptions{
+		Start: hdate.New(5783, hdate.Kislev, 30),
+		End:   hdate.New(5783, hdate.Tevet, 1),
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(nil, err)
+	doc := hebcal.NewJSONDocument(events, &opts, "en")
+	items := map[string]hebcal.JSONItem{}
+	for _, item := range doc.Items {
+		items[item.Date+" "+item.Title] = item
+	}
+	// Shabbat Rosh Chodesh during Chanukah adds to the weekly parsha
+	assert.Equal(map[string]string{
+		"7":        "Numbers 28:9-15",
+		"maftir":   "Numbers 7:42-47",
+		"haftarah": "Zechariah 2:14-4:7",
+	}, items["2022-12-24 Rosh Chodesh Tevet"].Leyning)
+	assert.Equal(map[string]string{
+		"1": "Numbers 28:1-5",
+		"2": "Numbers 28:6-10",
+		"3": "Numbers 28:11-15",
+		"4": "Numbers 7:48-53",
+	}, items["2022-12-25 Chanukah: 8 Candles"].Leyning)
+	assert.Nil(items["2022-12-24 Chag HaBanot"].Leyning)
+}
@@ -0,0 +1,93 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"strings"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+)
+
+// maxSearchYears limits how far NextHoliday and PrevHoliday look.
+// The rarest recurring event, Birkat Hachamah, occurs every 28 years.
+const maxSearchYears = 30
+
+// matchHoliday reports whether ev is an occurrence of name. An event
+// matches if its description is exactly name (e.g. "Erev Pesach"),
+// or if its Basename is name and it is not an "Erev ..." event, so
+// that "Pesach" finds Pesach I rather than Erev Pesach.
+func matchHoliday(ev event.HolidayEvent, name string) bool {
+	if ev.Desc == name {
+		return true
+	}
+	return !strings.HasPrefix(ev.Desc, "Erev ") && ev.Basename() == name
+}
+
+// NextHoliday returns the first holiday strictly after from whose
+// Basename() is basename, e.g. "Chanukah", "Tish'a B'Av" or
+// "Rosh Chodesh Adar". Erev events are skipped unless basename
+// names them exactly (e.g. "Erev Yom Kippur").
+// For Israel holiday schedule, specify il=true.
+//
+// The search crosses Hebrew year boundaries and gives up after 30
+// years, returning false if no such holiday was found.
+func NextHoliday(basename string, from hdate.HDate, il bool) (event.HolidayEvent, bool) {
+	fromAbs := from.Abs()
+	for year := from.Year(); year <= from.Year()+maxSearchYears; year++ {
+		for _, ev := range getHolidayYear(year, il).events {
+			if ev.Date.Abs() > fromAbs && matchHoliday(ev, basename) {
+				return ev, true
+			}
+		}
+	}
+	return event.HolidayEvent{}, false
+}
+
+// PrevHoliday is like NextHoliday, but returns the last occurrence
+// strictly before from.
+func PrevHoliday(basename string, from hdate.HDate, il bool) (event.HolidayEvent, bool) {
+	fromAbs := from.Abs()
+	for year := from.Year(); year >= 1 && year >= from.Year()-maxSearchYears; year-- {
+		events := getHolidayYear(year, il).events
+		for i := len(events) - 1; i >= 0; i-- {
+			ev := events[i]
+			if ev.Date.Abs() < fromAbs && matchHoliday(ev, basename) {
+				return ev, true
+			}
+		}
+	}
+	return event.HolidayEvent{}, false
+}
+
+// HolidaysBetween returns the holidays from start to end (inclusive)
+// for which predicate returns true, in date order. A nil predicate
+// matches every holiday.
+// For Israel holiday schedule, specify il=true.
+func HolidaysBetween(start, end hdate.HDate, il bool, predicate func(ev event.HolidayEvent) bool) []event.HolidayEvent {
+	startAbs, endAbs := start.Abs(), end.Abs()
+	result := make([]event.HolidayEvent, 0)
+	for year := start.Year(); year <= end.Year(); year++ {
+		for _, ev := range getHolidayYear(year, il).events {
+			abs := ev.Date.Abs()
+			if abs >= startAbs && abs <= endAbs && (predicate == nil || predicate(ev)) {
+				result = append(result, ev)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package hebcal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHoliday(t *testing.T) {
+	from := hdate.FromGregorian(2022, time.September, 1)
+	ev, ok := hebcal.NextHoliday("Chanukah", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "Chanukah: 1 Candle", ev.Desc)
+	assert.Equal(t, "2022-12-18", hd2iso(ev.Date))
+
+	// crosses into the next Hebrew year
+	ev, ok = hebcal.NextHoliday("Tish'a B'Av", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "2023-07-27", hd2iso(ev.Date))
+
+	ev, ok = hebcal.NextHoliday("Pesach", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "Pesach I", ev.Desc)
+	ev, ok = hebcal.NextHoliday("Erev Pesach", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "2023-04-05", hd2iso(ev.Date))
+
+	// strictly after from
+	ev, _ = hebcal.NextHoliday("Yom Kippur", hdate.New(5783, hdate.Tishrei, 10), false)
+	assert.Equal(t, 5784, ev.Date.Year())
+
+	ev, ok = hebcal.NextHoliday("Rosh Chodesh Adar", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "2023-02-21", hd2iso(ev.Date))
+
+	ev, ok = hebcal.NextHoliday("Birkat Hachamah", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "2037-04-08", hd2iso(ev.Date))
+
+	_, ok = hebcal.NextHoliday("No Such Holiday", from, false)
+	assert.False(t, ok)
+}
+
+func TestNextHolidayIsrael(t *testing.T) {
+	from := hdate.FromGregorian(2023, time.January, 1)
+	_, ok := hebcal.NextHoliday("Pesach VIII", from, false)
+	assert.True(t, ok)
+	_, ok = hebcal.NextHoliday("Pesach VIII", from, true)
+	assert.False(t, ok)
+	ev, _ := hebcal.NextHoliday("Yom HaAtzma'ut", from, true)
+	assert.Equal(t, "2023-04-26", hd2iso(ev.Date))
+}
+
+func TestPrevHoliday(t *testing.T) {
+	from := hdate.FromGregorian(2022, time.September, 1)
+	ev, ok := hebcal.PrevHoliday("Purim", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "2022-03-17", hd2iso(ev.Date))
+	ev, ok = hebcal.PrevHoliday("Chanukah", from, false)
+	assert.True(t, ok)
+	assert.Equal(t, "Chanukah: 8th Day", ev.Desc)
+	assert.Equal(t, "2021-12-06", hd2iso(ev.Date))
+}
+
+func TestHolidaysBetween(t *testing.T) {
+	start := hdate.FromGregorian(2022, time.September, 1)
+	end := hdate.FromGregorian(2022, time.October, 31)
+	events := hebcal.HolidaysBetween(start, end, false, func(ev event.HolidayEvent) bool {
+		return (ev.Flags & event.ROSH_CHODESH) != 0
+	})
+	actual := make([]string, len(events))
+	for i, ev := range events {
+		actual[i] = hd2iso(ev.Date) + " " + ev.Desc
+	}
+	expected := []string{
+		"2022-10-25 Rosh Chodesh Cheshvan",
+		"2022-10-26 Rosh Chodesh Cheshvan",
+	}
+	assert.Equal(t, expected, actual)
+
+	all := hebcal.HolidaysBetween(start, end, true, nil)
+	assert.Equal(t, "2022-09-17", hd2iso(all[0].Date))
+	assert.Equal(t, "Leil Selichot", all[0].Desc)
+	for _, ev := range all {
+		assert.Equal(t, event.HolidayFlags(0), ev.Flags&event.CHUL_ONLY)
+	}
+}
@@ -19,10 +19,11 @@ package hebcal
 import (
 	"time"
 
-	"github.com/hebcal/hdate"
 	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/rambam"
 	"github.com/MaxBGreenberg/hebcal-go/yerushalmi"
 	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/hebcal/hdate"
 )
 
 // UserEvent is used for generating a non-yahrtzeit user event.
@@ -69,8 +70,17 @@ type CalOptions struct {
 	HavdalahDeg float64
 	/* calculate parashah hashavua on Saturdays */
 	Sedrot bool
+	/* attach the triennial cycle reading to parashah events */
+	Triennial bool
+	/* Monday, Thursday and Shabbat Mincha Torah readings */
+	WeekdayReadings bool
+	/* include Pirkei Avot on Shabbat afternoons between Pesach and Rosh Hashana */
+	PirkeiAvot bool
 	/* Israeli holiday and sedra schedule */
 	IL bool
+	// Emit both the Israel and Diaspora schedules, marking each event
+	// that occurs in only one with a ScheduleEvent. IL is ignored.
+	BothSchedules bool
 	/* suppress minor fasts */
 	NoMinorFast bool
 	/* suppress modern holidays */
@@ -84,12 +94,26 @@ type CalOptions struct {
 	NoHolidays bool
 	/* include Babylonian Talmud Daf Yomi */
 	DafYomi bool
+	/* include Babylonian Talmud Amud Yomi, one side of a daf each day */
+	AmudYomi bool
+	/* include Babylonian Talmud Daf HaShavua, one daf each week */
+	DafWeekly bool
 	/* include Mishna Yomi */
 	MishnaYomi bool
 	/* include Jerusalem Talmud Daf Yomi */
 	YerushalmiYomi bool
 	/* include Nach Yomi */
 	NachYomi bool
+	/* include Rambam Yomi */
+	RambamYomi bool
+	/* Either the three chapter or one chapter Rambam Yomi cycle */
+	RambamCycle rambam.Cycle
+	/* include the 929 daily chapter of Tanakh */
+	Tanakh929 bool
+	/* include Tehillim divided over the days of the Hebrew month */
+	TehillimMonthly bool
+	/* include Tehillim divided over the days of the week */
+	TehillimWeekly bool
 	/* Either the Vilna or Schottenstein edition of Yerushalmi Yomi */
 	YerushalmiEdition yerushalmi.Edition
 	/* include Days of the Omer */
@@ -116,6 +140,10 @@ type CalOptions struct {
 	// Whether to use 24-hour time (as opposed to 12-hour time) for
 	// TimedEvent.Render().
 	Hour24 bool
+	// Locale used when rendering event titles (e.g. "he", "ashkenazi").
+	// HebrewCalendar ignores this; it is set by ParseQuery from the
+	// lg parameter for use by the serializers.
+	Locale string
 
 	//	-------- Begin: CLI legacy compatibility options  --------
 	//  These options are primarily here for the command-line interface.
@@ -0,0 +1,34 @@
+package hebcal_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPirkeiAvotEvents(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:      hdate.FromGregorian(2023, time.August, 19),
+		End:        hdate.FromGregorian(2023, time.September, 9),
+		NoHolidays: true,
+		PirkeiAvot: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s,%s", hd2iso(ev.GetDate()), ev.Render("en"), ev.Render("he"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-08-19,Pirkei Avot 6,פִּרְקֵי אָבוֹת ו׳",
+		"2023-08-26,Pirkei Avot 1-2,פִּרְקֵי אָבוֹת א׳־ב׳",
+		"2023-09-02,Pirkei Avot 3-4,פִּרְקֵי אָבוֹת ג׳־ד׳",
+		"2023-09-09,Pirkei Avot 5-6,פִּרְקֵי אָבוֹת ה׳־ו׳",
+	}
+	assert.Equal(t, expected, actual)
+}
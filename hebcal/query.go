@@ -0,0 +1,462 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/locales"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"gopkg.in/yaml.v3"
+)
+
+// Simple on/off query parameters and the CalOptions field each controls.
+var queryFlags = []struct {
+	key   string
+	field func(opts *CalOptions) *bool
+}{
+	{"s", func(o *CalOptions) *bool { return &o.Sedrot }},
+	{"i", func(o *CalOptions) *bool { return &o.IL }},
+	{"c", func(o *CalOptions) *bool { return &o.CandleLighting }},
+	{"d", func(o *CalOptions) *bool { return &o.AddHebrewDates }},
+	{"D", func(o *CalOptions) *bool { return &o.AddHebrewDatesForEvents }},
+	{"o", func(o *CalOptions) *bool { return &o.Omer }},
+	{"F", func(o *CalOptions) *bool { return &o.DafYomi }},
+	{"myomi", func(o *CalOptions) *bool { return &o.MishnaYomi }},
+	{"yyomi", func(o *CalOptions) *bool { return &o.YerushalmiYomi }},
+	{"nyomi", func(o *CalOptions) *bool { return &o.NachYomi }},
+	{"ykk", func(o *CalOptions) *bool { return &o.YomKippurKatan }},
+	{"molad", func(o *CalOptions) *bool { return &o.Molad }},
+	{"mvch", func(o *CalOptions) *bool { return &o.ShabbatMevarchim }},
+}
+
+// Query parameters that include a category of holidays, and the
+// CalOptions field that suppresses it.
+var queryNegatedFlags = []struct {
+	key   string
+	field func(opts *CalOptions) *bool
+}{
+	{"nx", func(o *CalOptions) *bool { return &o.NoRoshChodesh }},
+	{"mf", func(o *CalOptions) *bool { return &o.NoMinorFast }},
+	{"ss", func(o *CalOptions) *bool { return &o.NoSpecialShabbat }},
+	{"mod", func(o *CalOptions) *bool { return &o.NoModern }},
+}
+
+// Event flags of the holidays controlled by maj and min. EREV is in
+// neither: Erev of a major holiday is matched by LIGHT_CANDLES (or by
+// MAJOR_FAST for Erev Tish'a B'Av) and Erev Purim by MINOR_HOLIDAY.
+const (
+	queryMajorFlags = event.CHAG | event.CHOL_HAMOED | event.MAJOR_FAST |
+		event.LIGHT_CANDLES | event.LIGHT_CANDLES_TZEIS | event.YOM_TOV_ENDS
+	queryMinorFlags = event.MINOR_HOLIDAY | event.CHANUKAH_CANDLES
+)
+
+// hebcal.com parameters that this package recognizes but cannot honor.
+// There is no Tanakh Yomi (dty) schedule in this module yet.
+var queryUnsupported = map[string]string{
+	"dty":       "Tanakh Yomi",
+	"zip":       "ZIP code lookup",
+	"geonameid": "GeoNames lookup",
+}
+
+func parseOnOff(q url.Values, key string) (bool, error) {
+	switch v := q.Get(key); strings.ToLower(v) {
+	case "", "off", "0", "false":
+		return false, nil
+	case "on", "1", "true":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s=%q: expected on or off", key, v)
+	}
+}
+
+func parseIntParam(q url.Values, key string, min, max int) (int, bool, error) {
+	v := q.Get(key)
+	if v == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min || n > max {
+		return 0, false, fmt.Errorf("%s=%q: expected an integer between %d and %d", key, v, min, max)
+	}
+	return n, true, nil
+}
+
+func parseDateParam(q url.Values, key string) (hdate.HDate, bool, error) {
+	v := q.Get(key)
+	if v == "" {
+		return hdate.HDate{}, false, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return hdate.HDate{}, false, fmt.Errorf("%s=%q: expected a date in YYYY-MM-DD format", key, v)
+	}
+	return hdate.FromTime(t), true, nil
+}
+
+func parseQueryLocation(q url.Values) (*zmanim.Location, error) {
+	geo := q.Get("geo")
+	if geo == "" {
+		if q.Get("city") != "" {
+			geo = "city"
+		} else if q.Get("latitude") != "" || q.Get("longitude") != "" {
+			geo = "pos"
+		}
+	}
+	switch geo {
+	case "", "none":
+		return nil, nil
+	case "city":
+		name := q.Get("city")
+		if name == "" {
+			return nil, errors.New("geo=city requires the city parameter")
+		}
+		loc := zmanim.LookupCity(name)
+		if loc == nil {
+			return nil, fmt.Errorf("city=%q: unknown city", name)
+		}
+		return loc, nil
+	case "pos":
+		lat, err := strconv.ParseFloat(q.Get("latitude"), 64)
+		if err != nil || lat < -90 || lat > 90 {
+			return nil, fmt.Errorf("latitude=%q: expected a number between -90 and 90", q.Get("latitude"))
+		}
+		long, err := strconv.ParseFloat(q.Get("longitude"), 64)
+		if err != nil || long < -180 || long > 180 {
+			return nil, fmt.Errorf("longitude=%q: expected a number between -180 and 180", q.Get("longitude"))
+		}
+		tzid := q.Get("tzid")
+		if tzid == "" {
+			return nil, errors.New("geo=pos requires the tzid parameter")
+		}
+		if _, err := time.LoadLocation(tzid); err != nil {
+			return nil, fmt.Errorf("tzid=%q: unknown time zone", tzid)
+		}
+		loc := zmanim.NewLocation("", "", lat, long, tzid)
+		return &loc, nil
+	}
+	if reason, ok := queryUnsupported[geo]; ok {
+		return nil, fmt.Errorf("geo=%s: %s is not supported", geo, reason)
+	}
+	return nil, fmt.Errorf("geo=%q: expected city, pos or none", geo)
+}
+
+func parseQueryLocale(q url.Values) (string, error) {
+	lg := q.Get("lg")
+	switch lg {
+	case "", "s", "sephardic":
+		return "en", nil
+	case "a":
+		return "ashkenazi", nil
+	case "h":
+		return "he", nil
+	}
+	for _, locale := range locales.AllLocales {
+		if lg == locale {
+			return lg, nil
+		}
+	}
+	return "", fmt.Errorf("lg=%q: unsupported locale", lg)
+}
+
+/*
+ParseQuery converts query parameters in the style of the hebcal.com
+REST API into CalOptions.
+
+Holidays and other events are off unless requested, as on hebcal.com:
+  - maj, min - major and minor holidays; when only one is on, Mask is set
+  - nx, mf, ss, mod - Rosh Chodesh, minor fasts, special Shabbatot, modern holidays
+  - s, i - weekly Torah portion; Israel schedule
+  - c, b, m, M - candle-lighting; minutes before sunset; Havdalah minutes or nightfall
+  - geo=city&city=NAME, or geo=pos&latitude=...&longitude=...&tzid=...
+  - d, D, o, F, myomi, yyomi, nyomi, ykk, molad, mvch - see CalOptions
+  - year (or "now"), yt (G or H), month, ny - date range by year
+  - start, end (YYYY-MM-DD) - explicit date range
+  - lg - locale for titles (s, a, h, or a name from locales.AllLocales)
+
+Unrecognized parameters are ignored, so a full hebcal.com URL may be
+passed in. dty=on (Tanakh Yomi) returns an error, as this
+module has no Tanakh Yomi schedule. Invalid values and conflicting combinations return an error
+naming the offending parameter. year=now leaves Year as 0, which
+HebrewCalendar interprets as the current year.
+*/
+func ParseQuery(q url.Values) (*CalOptions, error) {
+	opts := &CalOptions{}
+	for key, reason := range queryUnsupported {
+		if on, _ := parseOnOff(q, key); on || (key != "dty" && q.Get(key) != "") {
+			return nil, fmt.Errorf("%s: %s is not supported", key, reason)
+		}
+	}
+	var err error
+	for _, f := range queryFlags {
+		if *f.field(opts), err = parseOnOff(q, f.key); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range queryNegatedFlags {
+		on, err := parseOnOff(q, f.key)
+		if err != nil {
+			return nil, err
+		}
+		*f.field(opts) = !on
+	}
+	maj, err := parseOnOff(q, "maj")
+	if err != nil {
+		return nil, err
+	}
+	min, err := parseOnOff(q, "min")
+	if err != nil {
+		return nil, err
+	}
+	opts.NoHolidays = !maj && !min
+
+	if opts.Location, err = parseQueryLocation(q); err != nil {
+		return nil, err
+	}
+	if opts.CandleLighting && opts.Location == nil {
+		return nil, errors.New("c=on requires a location (geo=city or geo=pos)")
+	}
+	if opts.CandleLightingMins, _, err = parseIntParam(q, "b", 0, 90); err != nil {
+		return nil, err
+	}
+	nightfall, err := parseOnOff(q, "M")
+	if err != nil {
+		return nil, err
+	}
+	var hasHavdalahMins bool
+	if opts.HavdalahMins, hasHavdalahMins, err = parseIntParam(q, "m", 0, 120); err != nil {
+		return nil, err
+	}
+	if nightfall && hasHavdalahMins && opts.HavdalahMins != 0 {
+		return nil, errors.New("M=on (Havdalah at nightfall) conflicts with m (Havdalah minutes)")
+	}
+	if opts.Locale, err = parseQueryLocale(q); err != nil {
+		return nil, err
+	}
+	if maj != min {
+		// include everything requested, less the other category of holidays
+		opts.Mask = getMaskFromOptions(opts) &^ (event.IL_ONLY | event.CHUL_ONLY | event.EREV)
+		if maj {
+			opts.Mask &^= queryMinorFlags
+		} else {
+			opts.Mask &^= queryMajorFlags
+		}
+	}
+
+	start, hasStart, err := parseDateParam(q, "start")
+	if err != nil {
+		return nil, err
+	}
+	end, hasEnd, err := parseDateParam(q, "end")
+	if err != nil {
+		return nil, err
+	}
+	if hasStart != hasEnd {
+		return nil, errors.New("start and end must be specified together")
+	}
+	if hasStart {
+		if q.Get("year") != "" || q.Get("month") != "" {
+			return nil, errors.New("start and end cannot be combined with year or month")
+		}
+		if end.Abs() < start.Abs() {
+			return nil, fmt.Errorf("end=%s is before start=%s", q.Get("end"), q.Get("start"))
+		}
+		opts.Start, opts.End = start, end
+		return opts, nil
+	}
+
+	switch yt := q.Get("yt"); yt {
+	case "", "G":
+	case "H":
+		opts.IsHebrewYear = true
+	default:
+		return nil, fmt.Errorf("yt=%q: expected G or H", yt)
+	}
+	if year := q.Get("year"); year != "" && year != "now" {
+		if opts.Year, _, err = parseIntParam(q, "year", 1, 9999); err != nil {
+			return nil, err
+		}
+	}
+	if opts.NumYears, _, err = parseIntParam(q, "ny", 1, 99); err != nil {
+		return nil, err
+	}
+	if month := q.Get("month"); month != "" && month != "x" {
+		if opts.IsHebrewYear {
+			return nil, errors.New("month cannot be combined with yt=H")
+		}
+		if opts.NumYears > 1 {
+			return nil, errors.New("month cannot be combined with ny")
+		}
+		m, _, err := parseIntParam(q, "month", 1, 12)
+		if err != nil {
+			return nil, err
+		}
+		opts.Month = time.Month(m)
+	}
+	return opts, nil
+}
+
+/*
+EncodeQuery is the inverse of ParseQuery: it returns hebcal.com-style
+query parameters describing opts, suitable for building a permalink.
+
+Options that have no query parameter equivalent (for example HavdalahDeg
+or UserEvents) are omitted. Mask is only used to tell maj and min apart. A Location is encoded as
+geo=city when it is one of the classic cities, and as geo=pos otherwise.
+*/
+func EncodeQuery(opts *CalOptions) url.Values {
+	q := url.Values{}
+	onOff := func(key string, on bool) {
+		if on {
+			q.Set(key, "on")
+		} else {
+			q.Set(key, "off")
+		}
+	}
+	if opts.Mask != 0 {
+		onOff("maj", opts.Mask&(event.CHAG|event.CHOL_HAMOED) != 0)
+		onOff("min", opts.Mask&event.MINOR_HOLIDAY != 0)
+	} else {
+		onOff("maj", !opts.NoHolidays)
+		onOff("min", !opts.NoHolidays)
+	}
+	for _, f := range queryNegatedFlags {
+		onOff(f.key, !*f.field(opts))
+	}
+	for _, f := range queryFlags {
+		if *f.field(opts) {
+			q.Set(f.key, "on")
+		}
+	}
+	if loc := opts.Location; loc != nil {
+		city := zmanim.LookupCity(loc.Name)
+		if city != nil && city.Latitude == loc.Latitude && city.Longitude == loc.Longitude {
+			q.Set("geo", "city")
+			q.Set("city", city.Name)
+		} else {
+			q.Set("geo", "pos")
+			q.Set("latitude", strconv.FormatFloat(loc.Latitude, 'f', -1, 64))
+			q.Set("longitude", strconv.FormatFloat(loc.Longitude, 'f', -1, 64))
+			q.Set("tzid", loc.TimeZoneId)
+		}
+	}
+	if opts.CandleLightingMins != 0 {
+		q.Set("b", strconv.Itoa(intAbs(opts.CandleLightingMins)))
+	}
+	if opts.HavdalahMins != 0 {
+		q.Set("m", strconv.Itoa(intAbs(opts.HavdalahMins)))
+	} else if opts.CandleLighting {
+		q.Set("M", "on")
+	}
+	switch opts.Locale {
+	case "", "en":
+		q.Set("lg", "s")
+	case "ashkenazi":
+		q.Set("lg", "a")
+	case "he":
+		q.Set("lg", "h")
+	default:
+		q.Set("lg", opts.Locale)
+	}
+	if (opts.Start != hdate.HDate{}) && (opts.End != hdate.HDate{}) {
+		q.Set("start", opts.Start.Gregorian().Format("2006-01-02"))
+		q.Set("end", opts.End.Gregorian().Format("2006-01-02"))
+		return q
+	}
+	if opts.Year == 0 {
+		q.Set("year", "now")
+	} else {
+		q.Set("year", strconv.Itoa(opts.Year))
+	}
+	if opts.IsHebrewYear {
+		q.Set("yt", "H")
+	} else {
+		q.Set("yt", "G")
+	}
+	if opts.Month != 0 && !opts.IsHebrewYear {
+		q.Set("month", strconv.Itoa(int(opts.Month)))
+	} else {
+		q.Set("month", "x")
+	}
+	if opts.NumYears > 1 {
+		q.Set("ny", strconv.Itoa(opts.NumYears))
+	}
+	return q
+}
+
+// configToQuery flattens a decoded JSON or YAML config object into
+// query parameters. Booleans become on/off and numbers are formatted
+// in decimal.
+func configToQuery(config map[string]interface{}) (url.Values, error) {
+	q := url.Values{}
+	for key, value := range config {
+		switch v := value.(type) {
+		case nil:
+		case bool:
+			if v {
+				q.Set(key, "on")
+			} else {
+				q.Set(key, "off")
+			}
+		case string:
+			q.Set(key, v)
+		case int:
+			q.Set(key, strconv.Itoa(v))
+		case float64:
+			q.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			return nil, fmt.Errorf("%s: unsupported value of type %T", key, value)
+		}
+	}
+	return q, nil
+}
+
+func parseConfig(config map[string]interface{}) (*CalOptions, error) {
+	q, err := configToQuery(config)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQuery(q)
+}
+
+// ParseConfigJSON decodes a JSON object using the same parameter names
+// as ParseQuery, for example {"maj": true, "c": true, "city": "Boston"}.
+func ParseConfigJSON(b []byte) (*CalOptions, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, err
+	}
+	return parseConfig(config)
+}
+
+// ParseConfigYAML decodes a YAML mapping using the same parameter names
+// as ParseQuery.
+func ParseConfigYAML(b []byte) (*CalOptions, error) {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, err
+	}
+	return parseConfig(config)
+}
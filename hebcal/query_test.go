@@ -0,0 +1,223 @@
+package hebcal_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, _ := url.ParseQuery("v=1&cfg=json&maj=on&min=on&nx=on&mf=off&ss=on&mod=on&s=on&c=on" +
+		"&geo=city&city=Boston&b=20&m=50&lg=a&year=2022&month=10&o=on&F=on&i=off")
+	opts, err := hebcal.ParseQuery(q)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, opts.NoHolidays)
+	assert.Equal(t, false, opts.NoRoshChodesh)
+	assert.Equal(t, true, opts.NoMinorFast)
+	assert.Equal(t, false, opts.NoSpecialShabbat)
+	assert.Equal(t, false, opts.NoModern)
+	assert.Equal(t, true, opts.Sedrot)
+	assert.Equal(t, true, opts.CandleLighting)
+	assert.Equal(t, "Boston", opts.Location.Name)
+	assert.Equal(t, 20, opts.CandleLightingMins)
+	assert.Equal(t, 50, opts.HavdalahMins)
+	assert.Equal(t, "ashkenazi", opts.Locale)
+	assert.Equal(t, 2022, opts.Year)
+	assert.Equal(t, time.October, opts.Month)
+	assert.Equal(t, true, opts.Omer)
+	assert.Equal(t, true, opts.DafYomi)
+	assert.Equal(t, false, opts.IL)
+}
+
+func TestParseQueryDefaults(t *testing.T) {
+	opts, err := hebcal.ParseQuery(url.Values{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, opts.NoHolidays)
+	assert.Equal(t, 0, opts.Year)
+	assert.Equal(t, "en", opts.Locale)
+	assert.Nil(t, opts.Location)
+}
+
+func TestParseQueryPos(t *testing.T) {
+	q := url.Values{}
+	q.Set("geo", "pos")
+	q.Set("latitude", "40.7")
+	q.Set("longitude", "-74.0")
+	q.Set("tzid", "America/New_York")
+	q.Set("start", "2022-10-01")
+	q.Set("end", "2022-10-31")
+	opts, err := hebcal.ParseQuery(q)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 40.7, opts.Location.Latitude)
+	assert.Equal(t, "America/New_York", opts.Location.TimeZoneId)
+	assert.Equal(t, hdate.FromGregorian(2022, time.October, 1), opts.Start)
+	assert.Equal(t, hdate.FromGregorian(2022, time.October, 31), opts.End)
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{"c=on", "c=on requires a location (geo=city or geo=pos)"},
+		{"city=Nowhere", `city="Nowhere": unknown city`},
+		{"geo=pos&latitude=91&longitude=0&tzid=UTC", `latitude="91": expected a number between -90 and 90`},
+		{"geo=pos&latitude=10&longitude=0", "geo=pos requires the tzid parameter"},
+		{"geo=pos&latitude=10&longitude=0&tzid=Mars/Olympus", `tzid="Mars/Olympus": unknown time zone`},
+		{"geo=zip&zip=02138", "zip: ZIP code lookup is not supported"},
+		{"dty=on", "dty: Tanakh Yomi is not supported"},
+		{"maj=maybe", `maj="maybe": expected on or off`},
+		{"b=abc", `b="abc": expected an integer between 0 and 90`},
+		{"M=on&m=50", "M=on (Havdalah at nightfall) conflicts with m (Havdalah minutes)"},
+		{"lg=xx", `lg="xx": unsupported locale`},
+		{"start=2022-10-01", "start and end must be specified together"},
+		{"start=2022-10-10&end=2022-10-01", "end=2022-10-01 is before start=2022-10-10"},
+		{"start=2022-10-01&end=2022-10-10&year=2022", "start and end cannot be combined with year or month"},
+		{"yt=X", `yt="X": expected G or H`},
+		{"year=abc", `year="abc": expected an integer between 1 and 9999`},
+		{"yt=H&year=5783&month=3", "month cannot be combined with yt=H"},
+		{"month=13", `month="13": expected an integer between 1 and 12`},
+	}
+	for _, test := range tests {
+		q, _ := url.ParseQuery(test.query)
+		_, err := hebcal.ParseQuery(q)
+		if assert.Error(t, err, test.query) {
+			assert.Equal(t, test.err, err.Error(), test.query)
+		}
+	}
+}
+
+func TestEncodeQueryRoundTrip(t *testing.T) {
+	q, _ := url.ParseQuery("maj=on&min=on&nx=on&mf=on&ss=off&mod=on&s=on&c=on" +
+		"&geo=city&city=Jerusalem&b=40&M=on&lg=h&year=5783&yt=H&i=on&myomi=on")
+	opts, err := hebcal.ParseQuery(q)
+	assert.Equal(t, nil, err)
+	encoded := hebcal.EncodeQuery(opts)
+	assert.Equal(t, "Jerusalem", encoded.Get("city"))
+	assert.Equal(t, "h", encoded.Get("lg"))
+	assert.Equal(t, "off", encoded.Get("ss"))
+	opts2, err := hebcal.ParseQuery(encoded)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, opts, opts2)
+
+	opts = &hebcal.CalOptions{
+		Start:    hdate.New(5783, hdate.Tishrei, 1),
+		End:      hdate.New(5783, hdate.Tishrei, 30),
+		Location: opts.Location,
+	}
+	encoded = hebcal.EncodeQuery(opts)
+	assert.Equal(t, "2022-09-26", encoded.Get("start"))
+	assert.Equal(t, "2022-10-25", encoded.Get("end"))
+	assert.Equal(t, "", encoded.Get("year"))
+	opts2, err = hebcal.ParseQuery(encoded)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, opts.Start.String(), opts2.Start.String())
+	assert.Equal(t, opts.End.String(), opts2.End.String())
+}
+
+func TestEncodeQueryPos(t *testing.T) {
+	q, _ := url.ParseQuery("geo=pos&latitude=37.5&longitude=-122.25&tzid=America/Los_Angeles&year=now")
+	opts, err := hebcal.ParseQuery(q)
+	assert.Equal(t, nil, err)
+	encoded := hebcal.EncodeQuery(opts)
+	assert.Equal(t, "pos", encoded.Get("geo"))
+	assert.Equal(t, "37.5", encoded.Get("latitude"))
+	assert.Equal(t, "-122.25", encoded.Get("longitude"))
+	assert.Equal(t, "now", encoded.Get("year"))
+}
+
+func TestParseQueryMajorMinor(t *testing.T) {
+	render := func(query string) []string {
+		q, _ := url.ParseQuery(query + "&start=2023-03-05&end=2023-04-13")
+		opts, err := hebcal.ParseQuery(q)
+		assert.Equal(t, nil, err)
+		events, err := hebcal.HebrewCalendar(opts)
+		assert.Equal(t, nil, err)
+		var titles []string
+		for _, ev := range events {
+			titles = append(titles, ev.Render("en"))
+		}
+		return titles
+	}
+	both := render("maj=on&min=on")
+	assert.Contains(t, both, "Purim")
+	assert.Contains(t, both, "Erev Purim")
+	assert.Contains(t, both, "Pesach I")
+
+	major := render("maj=on&min=off")
+	assert.NotContains(t, major, "Purim")
+	assert.NotContains(t, major, "Erev Purim")
+	assert.Contains(t, major, "Erev Pesach")
+	assert.Contains(t, major, "Pesach I")
+	assert.Contains(t, major, "Pesach III (CH''M)")
+
+	minor := render("maj=off&min=on")
+	assert.Contains(t, minor, "Purim")
+	assert.Contains(t, minor, "Erev Purim")
+	assert.NotContains(t, minor, "Erev Pesach")
+	assert.NotContains(t, minor, "Pesach I")
+	assert.NotContains(t, minor, "Pesach III (CH''M)")
+
+	assert.Equal(t, 0, len(render("maj=off&min=off")))
+
+	// candle-lighting for Shabbat doesn't depend on maj
+	candles := render("maj=off&min=on&c=on&city=Boston")
+	assert.Contains(t, candles, "Candle lighting: 6:57")
+	assert.NotContains(t, candles, "Pesach I")
+
+	for _, query := range []string{"maj=on&min=off", "maj=off&min=on", "maj=on&min=on&c=on&city=Boston"} {
+		q, _ := url.ParseQuery(query)
+		opts, _ := hebcal.ParseQuery(q)
+		encoded := hebcal.EncodeQuery(opts)
+		assert.Equal(t, q.Get("maj"), encoded.Get("maj"), query)
+		assert.Equal(t, q.Get("min"), encoded.Get("min"), query)
+		opts2, err := hebcal.ParseQuery(encoded)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, opts.Mask, opts2.Mask, query)
+	}
+}
+
+func TestParseConfigJSON(t *testing.T) {
+	opts, err := hebcal.ParseConfigJSON([]byte(`{"maj": true, "c": true, "city": "Boston", "b": 18, "year": 2023, "s": "on"}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, opts.NoHolidays)
+	assert.Equal(t, true, opts.CandleLighting)
+	assert.Equal(t, "Boston", opts.Location.Name)
+	assert.Equal(t, 18, opts.CandleLightingMins)
+	assert.Equal(t, 2023, opts.Year)
+	assert.Equal(t, true, opts.Sedrot)
+
+	_, err = hebcal.ParseConfigJSON([]byte(`{"c": true}`))
+	assert.Equal(t, "c=on requires a location (geo=city or geo=pos)", err.Error())
+	_, err = hebcal.ParseConfigJSON([]byte(`{"city": ["Boston"]}`))
+	assert.Equal(t, "city: unsupported value of type []interface {}", err.Error())
+}
+
+func TestParseConfigYAML(t *testing.T) {
+	opts, err := hebcal.ParseConfigYAML([]byte("maj: true\nmin: true\ni: true\ngeo: city\ncity: Tel Aviv\nc: true\nyear: 5783\nyt: H\n"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, opts.IL)
+	assert.Equal(t, "Tel Aviv", opts.Location.Name)
+	assert.Equal(t, 5783, opts.Year)
+	assert.Equal(t, true, opts.IsHebrewYear)
+}
+
+func TestCalOptionsJSONRoundTrip(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:              hdate.New(5783, hdate.Nisan, 1),
+		End:                hdate.New(5783, hdate.Nisan, 30),
+		Sedrot:             true,
+		IL:                 true,
+		CandleLightingMins: 40,
+	}
+	b, err := json.Marshal(opts)
+	assert.Equal(t, nil, err)
+	var decoded hebcal.CalOptions
+	assert.Equal(t, nil, json.Unmarshal(b, &decoded))
+	assert.Equal(t, opts, decoded)
+}
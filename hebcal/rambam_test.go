@@ -0,0 +1,49 @@
+package hebcal_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/rambam"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRambamYomi(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:      hdate.FromGregorian(2023, time.April, 27),
+		End:        hdate.FromGregorian(2023, time.April, 29),
+		NoHolidays: true,
+		RambamYomi: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-04-27,Overview of Mishneh Torah Contents 10-12",
+		"2023-04-28,Overview of Mishneh Torah Contents 13-14, Foundations of the Torah 1",
+		"2023-04-29,Foundations of the Torah 2-4",
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "הלכות יסודי התורה ב׳־ד׳", events[2].Render("he"))
+}
+
+func TestRambamYomiOneChapter(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:       hdate.FromGregorian(2023, time.May, 10),
+		End:         hdate.FromGregorian(2023, time.May, 10),
+		NoHolidays:  true,
+		RambamYomi:  true,
+		RambamCycle: rambam.OneChapter,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "Foundations of the Torah 1", events[0].Render("en"))
+}
@@ -0,0 +1,147 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+)
+
+// RestrictedInterval is a [Start, End) period during which Shabbat or
+// Yom Tov is in effect. Consecutive days of Shabbat and Yom Tov, such
+// as two-day Yom Tov adjacent to Shabbat, form a single interval.
+type RestrictedInterval struct {
+	Start    time.Time   // Candle-lighting on the eve of FirstDay
+	End      time.Time   // Havdalah at the end of LastDay
+	FirstDay hdate.HDate // First day of Shabbat or Yom Tov
+	LastDay  hdate.HDate // Last day of Shabbat or Yom Tov
+}
+
+// Contains reports whether t falls within the interval.
+func (r RestrictedInterval) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// isRestrictedDay reports whether work is prohibited on hd,
+// i.e. it is Shabbat or Yom Tov.
+func isRestrictedDay(hd hdate.HDate, il bool) bool {
+	return hd.Weekday() == time.Saturday || isYomTov(hd, il)
+}
+
+// startOfDay returns local midnight at the start of hd.
+func startOfDay(hd hdate.HDate, opts *CalOptions) time.Time {
+	tz, _ := time.LoadLocation(opts.Location.TimeZoneId)
+	year, month, day := hd.Greg()
+	return time.Date(year, month, day, 0, 0, 0, 0, tz)
+}
+
+// makeRestrictedInterval computes candle-lighting before first and
+// Havdalah after last. Where the sun doesn't set, local midnight is
+// used instead.
+func makeRestrictedInterval(first, last hdate.HDate, opts *CalOptions) RestrictedInterval {
+	erev := first.Prev()
+	start := makeCandleEvent(erev, opts, nil).EventTime
+	if (start == time.Time{}) {
+		start = startOfDay(first, opts)
+	}
+	var havdalah TimedEvent
+	if last.Weekday() == time.Saturday {
+		havdalah = makeCandleEvent(last, opts, nil)
+	} else {
+		havdalah = makeCandleEvent(last, opts, event.HolidayEvent{Date: last, Flags: event.YOM_TOV_ENDS})
+	}
+	end := havdalah.EventTime
+	if (end == time.Time{}) {
+		end = startOfDay(last.Next(), opts)
+	}
+	return RestrictedInterval{Start: start, End: end, FirstDay: first, LastDay: last}
+}
+
+/*
+RestrictedIntervals returns the intervals during which Shabbat or Yom Tov
+is in effect at opts.Location, for every Shabbat or Yom Tov that touches
+the date range selected by opts (Year, Month, NumYears or Start/End),
+including one that begins on the evening of the last day in the range.
+
+Intervals begin at candle-lighting and end at Havdalah, computed exactly
+as HebrewCalendar does with CandleLighting enabled, so
+opts.CandleLightingMins, opts.HavdalahMins and opts.HavdalahDeg apply.
+Yom Tov follows the Israel schedule if opts.IL is set or the location
+is in Israel. Other CalOptions fields are ignored.
+*/
+func RestrictedIntervals(opts *CalOptions) ([]RestrictedInterval, error) {
+	if opts.Location == nil {
+		return nil, errors.New("opts.Location is required")
+	}
+	candleOpts := *opts
+	candleOpts.CandleLighting = true
+	resolved, err := resolveOptions(&candleOpts)
+	if err != nil {
+		return nil, err
+	}
+	startAbs, endAbs, err := getStartAndEnd(resolved)
+	if err != nil {
+		return nil, err
+	}
+	il := resolved.IL
+	abs := startAbs
+	// back up to the beginning of a Shabbat or Yom Tov already in progress
+	for isRestrictedDay(hdate.FromRD(abs), il) {
+		abs--
+	}
+	intervals := make([]RestrictedInterval, 0)
+	for ; abs <= endAbs+1; abs++ {
+		first := hdate.FromRD(abs)
+		if !isRestrictedDay(first, il) {
+			continue
+		}
+		last := first
+		for next := last.Next(); isRestrictedDay(next, il); next = next.Next() {
+			last = next
+		}
+		intervals = append(intervals, makeRestrictedInterval(first, last, resolved))
+		abs = last.Abs()
+	}
+	return intervals, nil
+}
+
+// IsRestricted reports whether Shabbat or Yom Tov is in effect at instant
+// t at location, using the default candle-lighting and Havdalah times
+// (see HebrewCalendar).
+func IsRestricted(t time.Time, location *zmanim.Location) bool {
+	tz, err := time.LoadLocation(location.TimeZoneId)
+	if err != nil {
+		return false
+	}
+	year, month, day := t.In(tz).Date()
+	today := hdate.FromGregorian(year, month, day)
+	opts := CalOptions{Location: location, Start: today.Prev(), End: today}
+	intervals, err := RestrictedIntervals(&opts)
+	if err != nil {
+		return false
+	}
+	for _, r := range intervals {
+		if r.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
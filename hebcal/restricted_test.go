@@ -0,0 +1,74 @@
+package hebcal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func restrictedStrings(intervals []hebcal.RestrictedInterval) []string {
+	result := make([]string, len(intervals))
+	for i, r := range intervals {
+		result[i] = r.Start.Format("2006-01-02 15:04") + " - " + r.End.Format("2006-01-02 15:04")
+	}
+	return result
+}
+
+func TestRestrictedIntervals(t *testing.T) {
+	intervals, err := hebcal.RestrictedIntervals(&hebcal.CalOptions{
+		Location: zmanim.LookupCity("Chicago"),
+		Start:    hdate.FromGregorian(2023, time.September, 14),
+		End:      hdate.FromGregorian(2023, time.October, 6),
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{
+		"2023-09-15 18:43 - 2023-09-17 19:39", // Rosh Hashana after Shabbat
+		"2023-09-22 18:31 - 2023-09-23 19:28",
+		"2023-09-24 18:27 - 2023-09-25 19:25", // Yom Kippur
+		"2023-09-29 18:18 - 2023-10-01 19:14", // Sukkot
+		"2023-10-06 18:06 - 2023-10-08 19:02", // begins on the last evening
+	}, restrictedStrings(intervals))
+	assert.Equal(t, "2023-09-30", hd2iso(intervals[3].FirstDay))
+	assert.Equal(t, "2023-10-01", hd2iso(intervals[3].LastDay))
+}
+
+func TestRestrictedIntervalsIsrael(t *testing.T) {
+	intervals, err := hebcal.RestrictedIntervals(&hebcal.CalOptions{
+		Location: zmanim.LookupCity("Jerusalem"),
+		Start:    hdate.FromGregorian(2023, time.September, 29),
+		End:      hdate.FromGregorian(2023, time.September, 30),
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2023-09-29 17:47 - 2023-09-30 19:02"}, restrictedStrings(intervals))
+}
+
+func TestRestrictedIntervalsInProgress(t *testing.T) {
+	intervals, err := hebcal.RestrictedIntervals(&hebcal.CalOptions{
+		Location:     zmanim.LookupCity("Chicago"),
+		Start:        hdate.FromGregorian(2023, time.September, 17),
+		End:          hdate.FromGregorian(2023, time.September, 18),
+		HavdalahMins: 50,
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2023-09-15 18:43 - 2023-09-17 19:48"}, restrictedStrings(intervals))
+}
+
+func TestRestrictedIntervalsRequiresLocation(t *testing.T) {
+	_, err := hebcal.RestrictedIntervals(&hebcal.CalOptions{Year: 2023})
+	assert.NotNil(t, err)
+}
+
+func TestIsRestricted(t *testing.T) {
+	loc := zmanim.LookupCity("Chicago")
+	tz, _ := time.LoadLocation(loc.TimeZoneId)
+	assert.Equal(t, false, hebcal.IsRestricted(time.Date(2023, time.September, 15, 18, 42, 0, 0, tz), loc))
+	assert.Equal(t, true, hebcal.IsRestricted(time.Date(2023, time.September, 15, 18, 43, 0, 0, tz), loc))
+	assert.Equal(t, true, hebcal.IsRestricted(time.Date(2023, time.September, 17, 12, 0, 0, 0, tz), loc))
+	assert.Equal(t, false, hebcal.IsRestricted(time.Date(2023, time.September, 17, 19, 40, 0, 0, tz), loc))
+	assert.Equal(t, false, hebcal.IsRestricted(time.Date(2023, time.September, 18, 12, 0, 0, 0, tz), loc))
+	assert.Equal(t, true, hebcal.IsRestricted(time.Date(2023, time.September, 25, 1, 0, 0, 0, tz), loc))
+}
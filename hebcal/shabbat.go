@@ -0,0 +1,168 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/molad"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+)
+
+// ShabbatWeek is the result of ShabbatSummary: everything a
+// synagogue newsletter or Shabbat widget shows for one week.
+type ShabbatWeek struct {
+	// First and last day covered. End is normally Shabbat, but is
+	// extended when Yom Tov immediately follows Shabbat.
+	Start, End hdate.HDate
+	Location   *zmanim.Location
+	// Candle-lighting for Shabbat and any Yom Tov in the week, in order.
+	// When Yom Tov is adjacent to Shabbat there is more than one.
+	CandleLighting []TimedEvent
+	// Havdalah (or end of Yom Tov) times, in order. The last element
+	// is Havdalah after the final day of Shabbat or Yom Tov.
+	Havdalah []TimedEvent
+	// Torah reading for Shabbat. Parsha.Chag is true if a holiday
+	// reading replaces the weekly portion.
+	Parsha     sedra.Parsha
+	ParshaDate hdate.HDate
+	// Holidays, Rosh Chodesh and fasts during the week
+	Holidays []event.HolidayEvent
+	// Special Shabbat name (e.g. "Shabbat Zachor"), or empty
+	SpecialShabbat string
+	// Month announced on Shabbat Mevarchim and its molad; empty/nil
+	// unless this Shabbat is Shabbat Mevarchim
+	MevarchimMonth string
+	Molad          *molad.Molad
+	// All calendar events for the week in HebrewCalendar order,
+	// suitable for rendering
+	Events []event.CalEvent
+}
+
+// isYomTov reports whether hd is a day of Yom Tov on which
+// candles are lit the evening before.
+func isYomTov(hd hdate.HDate, il bool) bool {
+	for _, ev := range getHolidayYear(hd.Year(), il).byDay[hd.Abs()] {
+		if (ev.Flags & event.CHAG) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+ShabbatSummary returns candle-lighting and Havdalah times, the weekly
+Torah portion, holidays, special Shabbat and Shabbat Mevarchim details
+for the week from date through the following Shabbat at loc.
+
+When Yom Tov falls immediately after Shabbat, the week is extended
+through the last day of Yom Tov so that Havdalah follows the final day.
+Yom Tov on Thursday and Friday yields candle-lighting on both Wednesday
+and Thursday evenings in addition to Shabbat.
+
+opts may be nil. If given, its candle-lighting and Havdalah settings
+(CandleLightingMins, HavdalahMins, HavdalahDeg), IL, Hour24 and
+holiday suppression options (NoModern, NoMinorFast, NoRoshChodesh,
+NoSpecialShabbat) are honored; its date range and Location are ignored.
+*/
+func ShabbatSummary(loc *zmanim.Location, date hdate.HDate, opts *CalOptions) (ShabbatWeek, error) {
+	if loc == nil {
+		return ShabbatWeek{}, errors.New("ShabbatSummary requires a location")
+	}
+	var calOpts CalOptions
+	if opts != nil {
+		calOpts = CalOptions{
+			CandleLightingMins: opts.CandleLightingMins,
+			HavdalahMins:       opts.HavdalahMins,
+			HavdalahDeg:        opts.HavdalahDeg,
+			IL:                 opts.IL,
+			Hour24:             opts.Hour24,
+			NoModern:           opts.NoModern,
+			NoMinorFast:        opts.NoMinorFast,
+			NoRoshChodesh:      opts.NoRoshChodesh,
+			NoSpecialShabbat:   opts.NoSpecialShabbat,
+		}
+	}
+	if loc.CountryCode == "IL" {
+		calOpts.IL = true
+	}
+	il := calOpts.IL
+	start := hdate.FromRD(date.Abs())
+	saturday := start.OnOrAfter(time.Saturday)
+	end := saturday
+	for next := end.Next(); isYomTov(next, il); next = next.Next() {
+		end = next
+	}
+	calOpts.Location = loc
+	calOpts.Start = start
+	calOpts.End = end
+	calOpts.CandleLighting = true
+	calOpts.Sedrot = true
+
+	week := ShabbatWeek{
+		Start:          start,
+		End:            end,
+		Location:       loc,
+		CandleLighting: make([]TimedEvent, 0, 2),
+		Havdalah:       make([]TimedEvent, 0, 1),
+		Holidays:       make([]event.HolidayEvent, 0),
+		ParshaDate:     saturday,
+	}
+	var err error
+	week.Events, err = HebrewCalendar(&calOpts)
+	if err != nil {
+		return ShabbatWeek{}, err
+	}
+	sedraYear := sedra.New(saturday.Year(), il)
+	week.Parsha = sedraYear.Lookup(saturday)
+
+	for _, ev := range week.Events {
+		switch ev := ev.(type) {
+		case TimedEvent:
+			switch ev.Desc {
+			case "Candle lighting":
+				week.CandleLighting = append(week.CandleLighting, ev)
+			case "Havdalah":
+				week.Havdalah = append(week.Havdalah, ev)
+			case "Fast begins", "Fast ends":
+			default:
+				// Chanukah candle-lighting replaces the holiday event
+				week.Holidays = append(week.Holidays, ev.HolidayEvent)
+			}
+		case event.HolidayEvent:
+			switch {
+			case (ev.Flags & event.SPECIAL_SHABBAT) != 0:
+				week.SpecialShabbat = ev.Desc
+			case (ev.Flags & event.SHABBAT_MEVARCHIM) != 0:
+			default:
+				week.Holidays = append(week.Holidays, ev)
+			}
+		}
+	}
+
+	if saturday.Month() != hdate.Elul && saturday.Day() >= 23 && saturday.Day() <= 29 {
+		monthName, month := nextMonthName(saturday.Year(), saturday.Month())
+		m := molad.New(saturday.Year(), month)
+		week.MevarchimMonth = monthName
+		week.Molad = &m
+	}
+	return week, nil
+}
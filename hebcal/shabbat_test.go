@@ -0,0 +1,79 @@
+package hebcal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func timedEventStrings(events []hebcal.TimedEvent) []string {
+	result := make([]string, len(events))
+	for i, ev := range events {
+		result[i] = hd2iso(ev.Date) + " " + ev.Render("en")
+	}
+	return result
+}
+
+func TestShabbatSummary(t *testing.T) {
+	week, err := hebcal.ShabbatSummary(zmanim.LookupCity("Chicago"),
+		hdate.FromGregorian(2023, time.January, 17), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2023-01-17", hd2iso(week.Start))
+	assert.Equal(t, "2023-01-21", hd2iso(week.End))
+	assert.Equal(t, []string{"2023-01-20 Candle lighting: 4:31"}, timedEventStrings(week.CandleLighting))
+	assert.Equal(t, []string{"2023-01-21 Havdalah: 5:35"}, timedEventStrings(week.Havdalah))
+	assert.Equal(t, []string{"Vaera"}, week.Parsha.Name)
+	assert.Equal(t, "2023-01-21", hd2iso(week.ParshaDate))
+	assert.Equal(t, "Sh'vat", week.MevarchimMonth)
+	assert.NotNil(t, week.Molad)
+	assert.Equal(t, "", week.SpecialShabbat)
+	assert.Equal(t, 0, len(week.Holidays))
+}
+
+func TestShabbatSummaryYomTovBeforeShabbat(t *testing.T) {
+	week, err := hebcal.ShabbatSummary(zmanim.LookupCity("Chicago"),
+		hdate.FromGregorian(2023, time.May, 22), &hebcal.CalOptions{HavdalahMins: 50})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2023-05-27", hd2iso(week.End))
+	assert.Equal(t, []string{
+		"2023-05-25 Candle lighting: 7:54",
+		"2023-05-26 Candle lighting: 7:55",
+	}, timedEventStrings(week.CandleLighting))
+	assert.Equal(t, []string{"2023-05-27 Havdalah (50 min): 9:05"}, timedEventStrings(week.Havdalah))
+	assert.Equal(t, true, week.Parsha.Chag)
+	holidays := make([]string, len(week.Holidays))
+	for i, ev := range week.Holidays {
+		holidays[i] = ev.Desc
+	}
+	assert.Equal(t, []string{"Erev Shavuot", "Shavuot I", "Shavuot II"}, holidays)
+}
+
+func TestShabbatSummaryYomTovAfterShabbat(t *testing.T) {
+	week, err := hebcal.ShabbatSummary(zmanim.LookupCity("Chicago"),
+		hdate.FromGregorian(2023, time.September, 12), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2023-09-17", hd2iso(week.End))
+	assert.Equal(t, []string{
+		"2023-09-15 Candle lighting: 6:43",
+		"2023-09-16 Candle lighting: 7:41",
+	}, timedEventStrings(week.CandleLighting))
+	assert.Equal(t, []string{"2023-09-17 Havdalah: 7:39"}, timedEventStrings(week.Havdalah))
+	assert.Equal(t, true, week.Parsha.Chag)
+	assert.Equal(t, "2023-09-16", hd2iso(week.ParshaDate))
+	assert.Equal(t, "", week.MevarchimMonth)
+}
+
+func TestShabbatSummarySpecialShabbat(t *testing.T) {
+	week, err := hebcal.ShabbatSummary(zmanim.LookupCity("Jerusalem"),
+		hdate.FromGregorian(2023, time.March, 2), nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Shabbat Zachor", week.SpecialShabbat)
+	assert.Equal(t, []string{"2023-03-03 Candle lighting: 4:57"}, timedEventStrings(week.CandleLighting))
+
+	_, err = hebcal.ShabbatSummary(nil, hdate.FromGregorian(2023, time.March, 2), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package hebcal
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/dafyomi"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/mishnayomi"
+	"github.com/MaxBGreenberg/hebcal-go/molad"
+	"github.com/MaxBGreenberg/hebcal-go/nachyomi"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+	"github.com/MaxBGreenberg/hebcal-go/yerushalmi"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+)
+
+// DayZmanim holds the halachic times of day for a DaySummary.
+// These are the same times that CalOptions.DailyZmanim adds to a calendar.
+// A time is zero if it does not occur at the location on that day.
+type DayZmanim struct {
+	AlotHaShachar    time.Time
+	Misheyakir       time.Time
+	Sunrise          time.Time
+	SofZmanShmaMGA   time.Time
+	SofZmanShma      time.Time
+	SofZmanTfillaMGA time.Time
+	SofZmanTfilla    time.Time
+	Chatzot          time.Time
+	MinchaGedola     time.Time
+	MinchaKetana     time.Time
+	PlagHaMincha     time.Time
+	Sunset           time.Time
+	BeinHashmashos   time.Time
+	Tzeit            time.Time // 3 medium-sized stars (7.083°)
+}
+
+// DaySummary describes everything about a single Hebrew date.
+type DaySummary struct {
+	Date hdate.HDate
+	IL   bool // Israel holiday and sedra schedule
+	// Holidays, Rosh Chodesh, fasts and special Shabbatot on Date
+	Holidays []event.HolidayEvent
+	// Torah reading for the Shabbat on or after Date. Parsha.Chag is
+	// true if a holiday reading replaces the weekly portion.
+	Parsha     sedra.Parsha
+	ParshaDate hdate.HDate
+	// Day of the Omer (1-49), or 0 outside the Omer
+	OmerDay int
+	// Daily learning; nil before each cycle began
+	DafYomi    *dafyomi.Daf
+	MishnaYomi mishnayomi.MishnaPair
+	NachYomi   *dafyomi.Daf
+	// Vilna edition; also nil on Yom Kippur and Tish'a B'Av
+	YerushalmiYomi *dafyomi.Daf
+	// Molad of the coming month, announced on Shabbat Mevarchim
+	// (the Shabbat before Rosh Chodesh, except in Elul); otherwise nil
+	Molad      *molad.Molad
+	MoladMonth string
+	// Halachic times; nil unless a location was given
+	Zmanim *DayZmanim
+}
+
+var (
+	mishnaYomiOnce  sync.Once
+	mishnaYomiIndex mishnayomi.MishnaYomiIndex
+	nachYomiOnce    sync.Once
+	nachYomiIndex   nachyomi.NachYomiIndex
+)
+
+// NewDaySummary returns a summary of hd. If loc is non-nil, the
+// summary includes zmanim for that location, and a location in
+// Israel implies the Israel schedule regardless of il.
+func NewDaySummary(hd hdate.HDate, loc *zmanim.Location, il bool) DaySummary {
+	if loc != nil && loc.CountryCode == "IL" {
+		il = true
+	}
+	abs := hd.Abs()
+	s := DaySummary{Date: hdate.FromRD(abs), IL: il}
+	s.Holidays = make([]event.HolidayEvent, 0)
+	for _, ev := range getHolidayYear(hd.Year(), il).byDay[abs] {
+		s.Holidays = append(s.Holidays, ev)
+	}
+
+	s.ParshaDate = s.Date.OnOrAfter(time.Saturday)
+	sedraYear := sedra.New(s.ParshaDate.Year(), il)
+	s.Parsha = sedraYear.Lookup(s.ParshaDate)
+
+	beginOmer := hdate.ToRD(hd.Year(), hdate.Nisan, 16)
+	endOmer := hdate.ToRD(hd.Year(), hdate.Sivan, 5)
+	if abs >= beginOmer && abs <= endOmer {
+		s.OmerDay = int(abs - beginOmer + 1)
+	}
+
+	if daf, err := dafyomi.New(s.Date); err == nil {
+		s.DafYomi = &daf
+	}
+	if abs >= mishnayomi.MishnaYomiStart {
+		mishnaYomiOnce.Do(func() { mishnaYomiIndex = mishnayomi.MakeIndex() })
+		s.MishnaYomi, _ = mishnaYomiIndex.Lookup(s.Date)
+	}
+	if abs >= nachyomi.NachYomiStart {
+		nachYomiOnce.Do(func() { nachYomiIndex = nachyomi.MakeIndex() })
+		if chapter, err := nachYomiIndex.Lookup(s.Date); err == nil {
+			s.NachYomi = &chapter
+		}
+	}
+	if abs >= yerushalmi.VilnaStartRD {
+		if daf := yerushalmi.New(s.Date, yerushalmi.Vilna); daf.Blatt != 0 {
+			s.YerushalmiYomi = &daf
+		}
+	}
+
+	if s.Date.Weekday() == time.Saturday && s.Date.Month() != hdate.Elul &&
+		s.Date.Day() >= 23 && s.Date.Day() <= 29 {
+		monthName, month := nextMonthName(s.Date.Year(), s.Date.Month())
+		m := molad.New(s.Date.Year(), month)
+		s.Molad = &m
+		s.MoladMonth = monthName
+	}
+
+	if loc != nil {
+		year, month, day := s.Date.Greg()
+		z := zmanim.New(loc, time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+		s.Zmanim = &DayZmanim{
+			AlotHaShachar:    z.AlotHaShachar(),
+			Misheyakir:       z.MisheyakirMachmir(),
+			Sunrise:          z.Sunrise(),
+			SofZmanShmaMGA:   z.SofZmanShmaMGA(),
+			SofZmanShma:      z.SofZmanShma(),
+			SofZmanTfillaMGA: z.SofZmanTfillaMGA(),
+			SofZmanTfilla:    z.SofZmanTfilla(),
+			Chatzot:          z.Chatzot(),
+			MinchaGedola:     z.MinchaGedola(),
+			MinchaKetana:     z.MinchaKetana(),
+			PlagHaMincha:     z.PlagHaMincha(),
+			Sunset:           z.Sunset(),
+			BeinHashmashos:   z.BeinHashmashos(),
+			Tzeit:            z.Tzeit(zmanim.Tzeit3MediumStars),
+		}
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package hebcal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDaySummary(t *testing.T) {
+	hd := hdate.FromGregorian(2023, time.April, 22)
+	s := hebcal.NewDaySummary(hd, zmanim.LookupCity("Chicago"), false)
+	assert.Equal(t, "1 Iyyar 5783", s.Date.String())
+	assert.Equal(t, false, s.IL)
+	holidays := make([]string, len(s.Holidays))
+	for i, ev := range s.Holidays {
+		holidays[i] = ev.Desc
+	}
+	assert.Equal(t, []string{"Rosh Chodesh Iyyar"}, holidays)
+	assert.Equal(t, []string{"Tazria", "Metzora"}, s.Parsha.Name)
+	assert.Equal(t, "2023-04-22", hd2iso(s.ParshaDate))
+	assert.Equal(t, 16, s.OmerDay)
+	assert.Equal(t, "Sotah 24", s.DafYomi.String())
+	assert.Equal(t, 2, len(s.MishnaYomi))
+	assert.NotNil(t, s.NachYomi)
+	assert.NotNil(t, s.YerushalmiYomi)
+	assert.Nil(t, s.Molad)
+	assert.Equal(t, "5:59AM", s.Zmanim.Sunrise.Format(time.Kitchen))
+	assert.Equal(t, "7:38PM", s.Zmanim.Sunset.Format(time.Kitchen))
+
+	// agrees with the equivalent HebrewCalendar events
+	opts := hebcal.CalOptions{
+		Start:          hd,
+		End:            hd,
+		Omer:           true,
+		DafYomi:        true,
+		MishnaYomi:     true,
+		NachYomi:       true,
+		YerushalmiYomi: true,
+	}
+	events, _ := hebcal.HebrewCalendar(&opts)
+	rendered := make([]string, len(events))
+	for i, ev := range events {
+		rendered[i] = ev.Render("en")
+	}
+	assert.Contains(t, rendered, s.DafYomi.String())
+	assert.Contains(t, rendered, s.MishnaYomi.String())
+	assert.Contains(t, rendered, s.NachYomi.String())
+	assert.Contains(t, rendered, "Yerushalmi "+s.YerushalmiYomi.String())
+}
+
+func TestNewDaySummaryIsrael(t *testing.T) {
+	// Pesach VIII in the Diaspora is Shabbat; in Israel the weekly
+	// reading resumes a week earlier
+	hd := hdate.FromGregorian(2023, time.April, 13)
+	diaspora := hebcal.NewDaySummary(hd, nil, false)
+	assert.Equal(t, "Pesach VIII", diaspora.Holidays[0].Desc)
+	assert.Nil(t, diaspora.Zmanim)
+	israel := hebcal.NewDaySummary(hd, zmanim.LookupCity("Jerusalem"), false)
+	assert.Equal(t, true, israel.IL)
+	assert.Equal(t, 0, len(israel.Holidays))
+	assert.Equal(t, []string{"Shmini"}, israel.Parsha.Name)
+	assert.Equal(t, false, israel.Parsha.Chag)
+}
+
+func TestNewDaySummaryMolad(t *testing.T) {
+	hd := hdate.FromGregorian(2023, time.January, 21)
+	s := hebcal.NewDaySummary(hd, nil, false)
+	assert.Equal(t, "Sh'vat", s.MoladMonth)
+	assert.Equal(t, "Shabbat Mevarchim Chodesh Sh'vat", s.Holidays[0].Desc)
+	assert.Equal(t, "2023-01-21", hd2iso(s.Molad.Date))
+}
+
+func TestNewDaySummaryEarly(t *testing.T) {
+	s := hebcal.NewDaySummary(hdate.New(5600, hdate.Nisan, 1), nil, false)
+	assert.Nil(t, s.DafYomi)
+	assert.Nil(t, s.MishnaYomi)
+	assert.Nil(t, s.NachYomi)
+	assert.Nil(t, s.YerushalmiYomi)
+	assert.Equal(t, 0, s.OmerDay)
+}
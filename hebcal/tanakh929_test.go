@@ -0,0 +1,35 @@
+package hebcal_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTanakh929(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:      hdate.FromGregorian(2023, time.April, 4),
+		End:        hdate.FromGregorian(2023, time.April, 10),
+		NoHolidays: true,
+		Tanakh929:  true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-04-04,929: I Samuel 20",
+		"2023-04-05,929: I Samuel 21",
+		"2023-04-09,929: I Samuel 22",
+		"2023-04-10,929: I Samuel 23",
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "929: שְׁמוּאֵל רִאשׁוֹן כ׳", events[0].Render("he"))
+}
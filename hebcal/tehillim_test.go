@@ -0,0 +1,56 @@
+package hebcal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTehillim(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:           hdate.New(5784, hdate.Cheshvan, 27),
+		End:             hdate.New(5784, hdate.Kislev, 2),
+		NoHolidays:      true,
+		TehillimMonthly: true,
+		TehillimWeekly:  true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	expected := []string{
+		"2023-11-11,Tehillim 120-134",
+		"2023-11-11,Weekly Tehillim 120-150",
+		"2023-11-12,Tehillim 135-139",
+		"2023-11-12,Weekly Tehillim 1-29",
+		"2023-11-13,Tehillim 140-150",
+		"2023-11-13,Weekly Tehillim 30-50",
+		"2023-11-14,Tehillim 1-9",
+		"2023-11-14,Weekly Tehillim 51-72",
+		"2023-11-15,Tehillim 10-17",
+		"2023-11-15,Weekly Tehillim 73-89",
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "תְּהִלִּים ק״מ־ק״נ", events[4].Render("he"))
+}
+
+func TestTehillimPsalm119(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:           hdate.New(5784, hdate.Tishrei, 25),
+		End:             hdate.New(5784, hdate.Tishrei, 26),
+		NoHolidays:      true,
+		TehillimMonthly: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, "Tehillim 119:1-96", events[0].Render("en"))
+	assert.Equal(t, "Tehillim 119:97-176", events[1].Render("en"))
+	assert.Equal(t, "תְּהִלִּים קי״ט:צ״ז־קע״ו", events[1].Render("he"))
+}
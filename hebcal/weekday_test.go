@@ -0,0 +1,66 @@
+package hebcal_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekdayReadings(t *testing.T) {
+	opts := hebcal.CalOptions{
+		Start:           hdate.FromGregorian(2022, time.September, 22),
+		End:             hdate.FromGregorian(2022, time.October, 24),
+		NoHolidays:      true,
+		WeekdayReadings: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	actual := make([]string, 0, len(events))
+	for _, ev := range events {
+		line := fmt.Sprintf("%s,%s", hd2iso(ev.GetDate()), ev.Render("en"))
+		actual = append(actual, line)
+	}
+	// Rosh Hashana, Yom Kippur, Sukkot and Rosh Chodesh have
+	// their own readings
+	expected := []string{
+		"2022-09-22,Torah reading: Nitzavim",
+		"2022-09-24,Shabbat Mincha: Vayeilech",
+		"2022-09-29,Torah reading: Vayeilech",
+		"2022-10-01,Shabbat Mincha: Ha'azinu",
+		"2022-10-03,Torah reading: Ha'azinu",
+		"2022-10-06,Torah reading: Ha'azinu",
+		"2022-10-08,Shabbat Mincha: Vezot Haberakhah",
+		"2022-10-15,Shabbat Mincha: Vezot Haberakhah",
+		"2022-10-20,Torah reading: Bereshit",
+		"2022-10-22,Shabbat Mincha: Noach",
+		"2022-10-24,Torah reading: Noach",
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "מִנְחָה שֶׁל שַׁבָּת: נֹחַ", events[9].Render("he"))
+
+	item := hebcal.NewJSONItem(events[8], "en")
+	assert.Equal(t, "leyning", item.Category)
+	assert.Equal(t, map[string]string{
+		"torah": "Genesis 1:1-13",
+		"1":     "Genesis 1:1-5",
+		"2":     "Genesis 1:6-8",
+		"3":     "Genesis 1:9-13",
+	}, item.Leyning)
+}
+
+func TestWeekdayReadingsYomKippurMincha(t *testing.T) {
+	// Yom Kippur on Shabbat has its own Mincha reading
+	opts := hebcal.CalOptions{
+		Start:           hdate.FromGregorian(2024, time.October, 12),
+		End:             hdate.FromGregorian(2024, time.October, 12),
+		NoHolidays:      true,
+		WeekdayReadings: true,
+	}
+	events, err := hebcal.HebrewCalendar(&opts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(events))
+}
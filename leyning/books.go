@@ -0,0 +1,80 @@
+package leyning
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// The five books of the Torah
+const (
+	Genesis     = "Genesis"
+	Exodus      = "Exodus"
+	Leviticus   = "Leviticus"
+	Numbers     = "Numbers"
+	Deuteronomy = "Deuteronomy"
+)
+
+// Number of verses in each chapter of the Torah, following the
+// Masoretic (Hebrew) chapter and verse numbering.
+var chapterVerses = map[string][]int{
+	Genesis: {
+		31, 25, 24, 26, 32, 22, 24, 22, 29, 32,
+		32, 20, 18, 24, 21, 16, 27, 33, 38, 18,
+		34, 24, 20, 67, 34, 35, 46, 22, 35, 43,
+		54, 33, 20, 31, 29, 43, 36, 30, 23, 23,
+		57, 38, 34, 34, 28, 34, 31, 22, 33, 26,
+	},
+	Exodus: {
+		22, 25, 22, 31, 23, 30, 29, 28, 35, 29,
+		10, 51, 22, 31, 27, 36, 16, 27, 25, 23,
+		37, 30, 33, 18, 40, 37, 21, 43, 46, 38,
+		18, 35, 23, 35, 35, 38, 29, 31, 43, 38,
+	},
+	Leviticus: {
+		17, 16, 17, 35, 26, 23, 38, 36, 24, 20,
+		47, 8, 59, 57, 33, 34, 16, 30, 37, 27,
+		24, 33, 44, 23, 55, 46, 34,
+	},
+	Numbers: {
+		54, 34, 51, 49, 31, 27, 89, 26, 23, 36,
+		35, 16, 33, 45, 41, 35, 28, 32, 22, 29,
+		35, 41, 30, 25, 19, 65, 23, 31, 39, 17,
+		54, 42, 56, 29, 34, 13,
+	},
+	Deuteronomy: {
+		46, 37, 29, 49, 30, 25, 26, 20, 29, 22,
+		32, 31, 19, 29, 23, 22, 20, 22, 21, 20,
+		23, 29, 26, 22, 19, 19, 26, 69, 28, 20,
+		30, 52, 29, 12,
+	},
+}
+
+// countVerses returns the number of verses from begin through end
+// (inclusive) in book, or 0 if the range is invalid.
+func countVerses(book string, begin, end Verse) int {
+	chapters := chapterVerses[book]
+	if begin.Chapter < 1 || end.Chapter > len(chapters) || end.Less(begin) ||
+		begin.Verse < 1 || begin.Verse > chapters[begin.Chapter-1] ||
+		end.Verse < 1 || end.Verse > chapters[end.Chapter-1] {
+		return 0
+	}
+	if begin.Chapter == end.Chapter {
+		return end.Verse - begin.Verse + 1
+	}
+	total := chapters[begin.Chapter-1] - begin.Verse + 1
+	for ch := begin.Chapter + 1; ch < end.Chapter; ch++ {
+		total += chapters[ch-1]
+	}
+	return total + end.Verse
+}
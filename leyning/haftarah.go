@@ -0,0 +1,304 @@
+package leyning
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+)
+
+// Minhag selects between customs that read different haftarot.
+type Minhag int
+
+const (
+	// Ashkenazi custom
+	Ashkenazi Minhag = iota
+	// Sephardi custom
+	Sephardi
+)
+
+// Passage is a range of verses from a book of the Prophets.
+type Passage struct {
+	Book  string // e.g. "I Kings"
+	Begin Verse  // first verse read
+	End   Verse  // last verse read (inclusive)
+}
+
+// Returns a string representation such as "Isaiah 40:1-26"
+func (p Passage) String() string {
+	return p.Book + " " + formatRange(p.Begin, p.End)
+}
+
+// Haftarah is the reading from the Prophets that follows the Torah
+// reading. It usually is a single passage, but may skip verses or
+// combine books, e.g. "Hosea 14:2-10; Joel 2:15-27".
+type Haftarah []Passage
+
+// Returns a string representation such as "Jeremiah 2:4-28; 3:4".
+// The book name is omitted when a passage continues the same book.
+func (h Haftarah) String() string {
+	parts := make([]string, len(h))
+	for i, p := range h {
+		if i > 0 && h[i-1].Book == p.Book {
+			parts[i] = formatRange(p.Begin, p.End)
+		} else {
+			parts[i] = p.String()
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// newHaftarah parses a string such as "Jeremiah 2:4-28; 3:4".
+func newHaftarah(s string) Haftarah {
+	parts := strings.Split(s, "; ")
+	h := make(Haftarah, len(parts))
+	book := ""
+	for i, part := range parts {
+		if space := strings.LastIndex(part, " "); space != -1 {
+			book = part[:space]
+			part = part[space+1:]
+		}
+		begin, end := parseRange(part)
+		h[i] = Passage{Book: book, Begin: begin, End: end}
+	}
+	return h
+}
+
+// Haftarot for each parsha: Ashkenazi, then Sephardi if different
+var parshaHaftarot = map[string][2]string{
+	"Bereshit":    {"Isaiah 42:5-43:10", "Isaiah 42:5-21"},
+	"Noach":       {"Isaiah 54:1-55:5", "Isaiah 54:1-10"},
+	"Lech-Lecha":  {"Isaiah 40:27-41:16"},
+	"Vayera":      {"II Kings 4:1-37", "II Kings 4:1-23"},
+	"Chayei Sara": {"I Kings 1:1-31"},
+	"Toldot":      {"Malachi 1:1-2:7"},
+	"Vayetzei":    {"Hosea 12:13-14:10", "Hosea 11:7-12:12"},
+	"Vayishlach":  {"Hosea 11:7-12:12", "Obadiah 1:1-21"},
+	"Vayeshev":    {"Amos 2:6-3:8"},
+	"Miketz":      {"I Kings 3:15-4:1"},
+	"Vayigash":    {"Ezekiel 37:15-28"},
+	"Vayechi":     {"I Kings 2:1-12"},
+
+	"Shemot":           {"Isaiah 27:6-28:13; 29:22-23", "Jeremiah 1:1-2:3"},
+	"Vaera":            {"Ezekiel 28:25-29:21"},
+	"Bo":               {"Jeremiah 46:13-28"},
+	"Beshalach":        {"Judges 4:4-5:31", "Judges 5:1-31"},
+	"Yitro":            {"Isaiah 6:1-7:6; 9:5-6", "Isaiah 6:1-13"},
+	"Mishpatim":        {"Jeremiah 34:8-22; 33:25-26"},
+	"Terumah":          {"I Kings 5:26-6:13"},
+	"Tetzaveh":         {"Ezekiel 43:10-27"},
+	"Ki Tisa":          {"I Kings 18:1-39", "I Kings 18:20-39"},
+	"Vayakhel":         {"I Kings 7:40-50", "I Kings 7:13-26"},
+	"Pekudei":          {"I Kings 7:51-8:21", "I Kings 7:40-50"},
+	"Vayakhel-Pekudei": {"I Kings 7:51-8:21", "I Kings 7:40-50"},
+
+	"Vayikra":             {"Isaiah 43:21-44:23"},
+	"Tzav":                {"Jeremiah 7:21-8:3; 9:22-23"},
+	"Shmini":              {"II Samuel 6:1-7:17", "II Samuel 6:1-19"},
+	"Tazria":              {"II Kings 4:42-5:19"},
+	"Metzora":             {"II Kings 7:3-20"},
+	"Tazria-Metzora":      {"II Kings 7:3-20"},
+	"Achrei Mot":          {"Ezekiel 22:1-19", "Ezekiel 22:1-16"},
+	"Kedoshim":            {"Amos 9:7-15", "Ezekiel 20:2-20"},
+	"Achrei Mot-Kedoshim": {"Amos 9:7-15", "Ezekiel 20:2-20"},
+	"Emor":                {"Ezekiel 44:15-31"},
+	"Behar":               {"Jeremiah 32:6-27"},
+	"Bechukotai":          {"Jeremiah 16:19-17:14"},
+	"Behar-Bechukotai":    {"Jeremiah 16:19-17:14"},
+
+	"Bamidbar":     {"Hosea 2:1-22"},
+	"Nasso":        {"Judges 13:2-25"},
+	"Beha'alotcha": {"Zechariah 2:14-4:7"},
+	"Sh'lach":      {"Joshua 2:1-24"},
+	"Korach":       {"I Samuel 11:14-12:22"},
+	"Chukat":       {"Judges 11:1-33"},
+	"Balak":        {"Micah 5:6-6:8"},
+	"Chukat-Balak": {"Micah 5:6-6:8"},
+	"Pinchas":      {"I Kings 18:46-19:21"},
+	"Matot":        {"Jeremiah 1:1-2:3"},
+	"Masei":        {"Jeremiah 2:4-28; 3:4", "Jeremiah 2:4-28; 4:1-2"},
+	"Matot-Masei":  {"Jeremiah 2:4-28; 3:4", "Jeremiah 2:4-28; 4:1-2"},
+
+	"Devarim":            {"Isaiah 1:1-27"},
+	"Vaetchanan":         {"Isaiah 40:1-26"},
+	"Eikev":              {"Isaiah 49:14-51:3"},
+	"Re'eh":              {"Isaiah 54:11-55:5"},
+	"Shoftim":            {"Isaiah 51:12-52:12"},
+	"Ki Teitzei":         {"Isaiah 54:1-10"},
+	"Ki Tavo":            {"Isaiah 60:1-22"},
+	"Nitzavim":           {"Isaiah 61:10-63:9"},
+	"Vayeilech":          {"Isaiah 55:6-56:8"},
+	"Nitzavim-Vayeilech": {"Isaiah 61:10-63:9"},
+	"Ha'azinu":           {"II Samuel 22:1-51"},
+	"Vezot Haberakhah":   {"Joshua 1:1-18", "Joshua 1:1-9"},
+}
+
+// Haftarot that replace the parsha's own on special Shabbatot:
+// Ashkenazi, then Sephardi if different
+var specialHaftarot = map[string][2]string{
+	"Chanukah":               {"Zechariah 2:14-4:7"},
+	"Chanukah Day 8":         {"I Kings 7:40-50"},
+	"Shabbat Shekalim":       {"II Kings 12:1-17", "II Kings 11:17-12:17"},
+	"Shabbat Zachor":         {"I Samuel 15:2-34", "I Samuel 15:1-34"},
+	"Shabbat Parah":          {"Ezekiel 36:16-38", "Ezekiel 36:16-36"},
+	"Shabbat HaChodesh":      {"Ezekiel 45:16-46:18", "Ezekiel 45:18-46:15"},
+	"Shabbat HaGadol":        {"Malachi 3:4-24"},
+	"Shabbat Shuva":          {"Hosea 14:2-10; Joel 2:15-27", "Hosea 14:2-10; Micah 7:18-20"},
+	"Shabbat Rosh Chodesh":   {"Isaiah 66:1-24"},
+	"Shabbat Machar Chodesh": {"I Samuel 20:18-42"},
+}
+
+// The three haftarot of affliction read between the 17th of Tammuz
+// and Tish'a B'Av, and the seven of consolation that follow
+var afflictionHaftarot = [][2]string{
+	{"Jeremiah 1:1-2:3"},
+	{"Jeremiah 2:4-28; 3:4", "Jeremiah 2:4-28; 4:1-2"},
+	{"Isaiah 1:1-27"},
+}
+
+var consolationHaftarot = [][2]string{
+	{"Isaiah 40:1-26"},
+	{"Isaiah 49:14-51:3"},
+	{"Isaiah 54:11-55:5"},
+	{"Isaiah 51:12-52:12"},
+	{"Isaiah 54:1-10"},
+	{"Isaiah 60:1-22"},
+	{"Isaiah 61:10-63:9"},
+}
+
+func selectMinhag(variants [2]string, minhag Minhag) Haftarah {
+	if minhag == Sephardi && variants[1] != "" {
+		return newHaftarah(variants[1])
+	}
+	return newHaftarah(variants[0])
+}
+
+// ParshaHaftarah returns the haftarah that belongs to a parsha
+// (see ParshaReadingByName for names), ignoring any special Shabbat
+// that may replace it; see LookupHaftarah.
+func ParshaHaftarah(name string, minhag Minhag) (Haftarah, error) {
+	variants, ok := parshaHaftarot[name]
+	if !ok {
+		return nil, errors.New("unknown parsha " + name)
+	}
+	return selectMinhag(variants, minhag), nil
+}
+
+// specialShabbat returns the name of the special Shabbat whose haftarah
+// replaces the parsha's own on saturday, or "" if there is none.
+// Overrides are checked in order of precedence: Chanukah, the four
+// parshiyot, Shabbat HaGadol and Shuva, then Rosh Chodesh and
+// Machar Chodesh. The haftarot of affliction and consolation are
+// handled by the caller; they take precedence over Rosh Chodesh.
+func specialShabbat(saturday hdate.HDate) string {
+	year := saturday.Year()
+	abs := saturday.Abs()
+	pesachAbs := hdate.ToRD(year, hdate.Nisan, 15)
+	chanukahDay := abs - hdate.ToRD(year, hdate.Kislev, 25) + 1
+	switch {
+	case chanukahDay == 8:
+		return "Chanukah Day 8"
+	case chanukahDay >= 1 && chanukahDay < 8:
+		return "Chanukah"
+	case abs == hdate.DayOnOrBefore(time.Saturday, pesachAbs-43):
+		return "Shabbat Shekalim"
+	case abs == hdate.DayOnOrBefore(time.Saturday, pesachAbs-30):
+		return "Shabbat Zachor"
+	case abs == hdate.DayOnOrBefore(time.Saturday, pesachAbs-14)-7:
+		return "Shabbat Parah"
+	case abs == hdate.DayOnOrBefore(time.Saturday, pesachAbs-14):
+		return "Shabbat HaChodesh"
+	case abs == hdate.DayOnOrBefore(time.Saturday, pesachAbs-1):
+		return "Shabbat HaGadol"
+	case abs == hdate.DayOnOrBefore(time.Saturday, hdate.ToRD(year, hdate.Tishrei, 8)):
+		return "Shabbat Shuva"
+	case saturday.Day() == 1 || saturday.Day() == 30:
+		return "Shabbat Rosh Chodesh"
+	case saturday.Day() == 29 && saturday.Month() != hdate.Elul:
+		return "Shabbat Machar Chodesh"
+	}
+	return ""
+}
+
+/*
+LookupHaftarah returns the haftarah read on the Shabbat on or after hd,
+according to the Israel (il=true) or Diaspora schedule and the given
+minhag.
+
+The parsha's own haftarah is replaced on Chanukah, the four special
+Shabbatot (Shekalim, Zachor, Parah and HaChodesh), Shabbat HaGadol,
+Shabbat Shuva, Shabbat Rosh Chodesh and Machar Chodesh, and on the
+three Shabbatot of affliction before Tish'a B'Av and the seven of
+consolation after it. When these coincide, Chanukah and the special
+Shabbatot take precedence, followed by the haftarot of affliction and
+consolation, then Rosh Chodesh and Machar Chodesh.
+
+The second return value names the occasion, e.g. "Shabbat Rosh
+Chodesh", or is empty when the parsha's own haftarah is read.
+Returns an error if a holiday reading replaces the weekly parsha.
+*/
+func LookupHaftarah(hd hdate.HDate, il bool, minhag Minhag) (Haftarah, string, error) {
+	saturday := hdate.FromRD(hd.Abs()).OnOrAfter(time.Saturday)
+	sedraYear := sedra.New(saturday.Year(), il)
+	parsha := sedraYear.Lookup(saturday)
+	if parsha.Chag || len(parsha.Name) == 0 {
+		return nil, "", errors.New("holiday Torah reading replaces the weekly parsha")
+	}
+	haftarah, err := ParshaHaftarah(strings.Join(parsha.Name, "-"), minhag)
+	if err != nil {
+		return nil, "", err
+	}
+
+	special := specialShabbat(saturday)
+	if special != "" && special != "Shabbat Rosh Chodesh" && special != "Shabbat Machar Chodesh" {
+		return selectMinhag(specialHaftarot[special], minhag), special, nil
+	}
+
+	av9 := hdate.New(saturday.Year(), hdate.Av, 9)
+	if av9.Weekday() == time.Saturday {
+		av9 = av9.Next()
+	}
+	chazon := hdate.DayOnOrBefore(time.Saturday, av9.Abs())
+	week := int((saturday.Abs() - chazon) / 7)
+	if saturday.Abs() < chazon {
+		week = -int((chazon - saturday.Abs()) / 7)
+	}
+	var seasonal [2]string
+	var reason string
+	switch {
+	case week >= -2 && week <= 0:
+		seasonal, reason = afflictionHaftarot[week+2], "Three Weeks of Affliction"
+	case week >= 1 && week <= 7:
+		seasonal, reason = consolationHaftarot[week-1], "Seven Weeks of Consolation"
+	}
+	if reason != "" {
+		h := selectMinhag(seasonal, minhag)
+		if h.String() == haftarah.String() {
+			reason = ""
+		}
+		return h, reason, nil
+	}
+
+	if special != "" {
+		return selectMinhag(specialHaftarot[special], minhag), special, nil
+	}
+	return haftarah, "", nil
+}
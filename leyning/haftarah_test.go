@@ -0,0 +1,93 @@
+package leyning_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/leyning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParshaHaftarah(t *testing.T) {
+	assert := assert.New(t)
+	h, err := leyning.ParshaHaftarah("Shemot", leyning.Ashkenazi)
+	assert.Nil(err)
+	assert.Equal("Isaiah 27:6-28:13; 29:22-23", h.String())
+	assert.Equal(leyning.Haftarah{
+		{Book: "Isaiah", Begin: leyning.Verse{Chapter: 27, Verse: 6}, End: leyning.Verse{Chapter: 28, Verse: 13}},
+		{Book: "Isaiah", Begin: leyning.Verse{Chapter: 29, Verse: 22}, End: leyning.Verse{Chapter: 29, Verse: 23}},
+	}, h)
+	h, err = leyning.ParshaHaftarah("Shemot", leyning.Sephardi)
+	assert.Nil(err)
+	assert.Equal("Jeremiah 1:1-2:3", h.String())
+	h, err = leyning.ParshaHaftarah("Matot-Masei", leyning.Ashkenazi)
+	assert.Nil(err)
+	assert.Equal("Jeremiah 2:4-28; 3:4", h.String())
+	assert.Equal(leyning.Verse{Chapter: 3, Verse: 4}, h[1].End)
+	_, err = leyning.ParshaHaftarah("Purim", leyning.Ashkenazi)
+	assert.NotNil(err)
+}
+
+func TestLookupHaftarah(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		minhag   leyning.Minhag
+		haftarah string
+		reason   string
+	}{
+		{time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Hosea 14:2-10; Joel 2:15-27", "Shabbat Shuva"},
+		{time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), leyning.Sephardi,
+			"Hosea 14:2-10; Micah 7:18-20", "Shabbat Shuva"},
+		{time.Date(2022, time.October, 8, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"II Samuel 22:1-51", ""},
+		// Chanukah on Shabbat Rosh Chodesh Tevet
+		{time.Date(2022, time.December, 24, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Zechariah 2:14-4:7", "Chanukah"},
+		// second Shabbat of Chanukah
+		{time.Date(2009, time.December, 19, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"I Kings 7:40-50", "Chanukah Day 8"},
+		{time.Date(2023, time.February, 18, 0, 0, 0, 0, time.UTC), leyning.Sephardi,
+			"II Kings 11:17-12:17", "Shabbat Shekalim"},
+		{time.Date(2023, time.March, 4, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"I Samuel 15:2-34", "Shabbat Zachor"},
+		{time.Date(2023, time.March, 18, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Ezekiel 45:16-46:18", "Shabbat HaChodesh"},
+		{time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Malachi 3:4-24", "Shabbat HaGadol"},
+		{time.Date(2023, time.April, 22, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Isaiah 66:1-24", "Shabbat Rosh Chodesh"},
+		{time.Date(2023, time.May, 20, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"I Samuel 20:18-42", "Shabbat Machar Chodesh"},
+		// Pinchas after the 17th of Tammuz
+		{time.Date(2023, time.July, 8, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Jeremiah 1:1-2:3", "Three Weeks of Affliction"},
+		{time.Date(2023, time.July, 15, 0, 0, 0, 0, time.UTC), leyning.Sephardi,
+			"Jeremiah 2:4-28; 4:1-2", ""},
+		// consolation takes precedence over Shabbat Rosh Chodesh Elul
+		{time.Date(2022, time.August, 27, 0, 0, 0, 0, time.UTC), leyning.Ashkenazi,
+			"Isaiah 54:11-55:5", ""},
+	}
+	for _, tt := range tests {
+		hd := hdate.FromTime(tt.date)
+		h, reason, err := leyning.LookupHaftarah(hd, false, tt.minhag)
+		assert.Nil(t, err, tt.date)
+		assert.Equal(t, tt.haftarah, h.String(), tt.date)
+		assert.Equal(t, tt.reason, reason, tt.date)
+	}
+}
+
+func TestLookupHaftarahHoliday(t *testing.T) {
+	// Shabbat Chol HaMoed Sukkot
+	_, _, err := leyning.LookupHaftarah(hdate.FromGregorian(2022, time.October, 15), false, leyning.Ashkenazi)
+	assert.NotNil(t, err)
+}
+
+func ExampleLookupHaftarah() {
+	hd := hdate.FromGregorian(2023, time.March, 11)
+	haftarah, reason, _ := leyning.LookupHaftarah(hd, false, leyning.Ashkenazi)
+	fmt.Println(haftarah, "-", reason)
+	// Output: Ezekiel 36:16-38 - Shabbat Parah
+}
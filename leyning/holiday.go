@@ -0,0 +1,472 @@
+package leyning
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+)
+
+// HolidayReading is the Torah reading and haftarah for a holiday,
+// Chol HaMoed, fast day, Rosh Chodesh or special Shabbat.
+type HolidayReading struct {
+	// Date of the reading
+	Date hdate.HDate
+	// Parsha is true if the weekly parsha is read first, as on Shabbat
+	// Rosh Chodesh or Shabbat Chanukah. In that case Aliyot holds only
+	// readings added after the parsha (e.g. Rosh Chodesh as the seventh
+	// aliyah when Chanukah supplies the maftir), and is often empty.
+	Parsha bool
+	// The aliyot, in order, excluding maftir
+	Aliyot []Aliyah
+	// Maftir; zero if there is none
+	Maftir Aliyah
+	// Haftarah; nil if there is none
+	Haftarah Haftarah
+	// Afternoon (Mincha) reading on Yom Kippur and fast days; otherwise nil
+	Mincha *HolidayReading
+}
+
+type festival struct {
+	weekday []string  // aliyot on a weekday
+	shabbat []string  // aliyot on Shabbat; nil if never on Shabbat
+	maftir  string    // "" if there is none
+	haftara [2]string // Ashkenazi, then Sephardi if different
+}
+
+var (
+	sukkotAliyot = []string{"Leviticus 22:26-33", "Leviticus 23:1-3", "Leviticus 23:4-14",
+		"Leviticus 23:15-22", "Leviticus 23:23-44"}
+	sukkotShabbatAliyot = []string{"Leviticus 22:26-33", "Leviticus 23:1-3", "Leviticus 23:4-8",
+		"Leviticus 23:9-14", "Leviticus 23:15-22", "Leviticus 23:23-32", "Leviticus 23:33-44"}
+	asserTeaserAliyot = []string{"Deuteronomy 15:19-23", "Deuteronomy 16:1-3", "Deuteronomy 16:4-8",
+		"Deuteronomy 16:9-12", "Deuteronomy 16:13-17"}
+	asserTeaserShabbatAliyot = []string{"Deuteronomy 14:22-29", "Deuteronomy 15:1-18",
+		"Deuteronomy 15:19-23", "Deuteronomy 16:1-3", "Deuteronomy 16:4-8",
+		"Deuteronomy 16:9-12", "Deuteronomy 16:13-17"}
+	shabbatCholHaMoedAliyot = []string{"Exodus 33:12-16", "Exodus 33:17-19", "Exodus 33:20-23",
+		"Exodus 34:1-3", "Exodus 34:4-10", "Exodus 34:11-17", "Exodus 34:18-26"}
+	fastAliyot = []string{"Exodus 32:11-14", "Exodus 34:1-3", "Exodus 34:4-10"}
+)
+
+// Readings for Yom Tov, keyed by event Desc
+var festivals = map[string]festival{
+	"Rosh Hashana I": {
+		weekday: []string{"Genesis 21:1-4", "Genesis 21:5-12", "Genesis 21:13-21",
+			"Genesis 21:22-27", "Genesis 21:28-34"},
+		shabbat: []string{"Genesis 21:1-4", "Genesis 21:5-8", "Genesis 21:9-12", "Genesis 21:13-17",
+			"Genesis 21:18-21", "Genesis 21:22-27", "Genesis 21:28-34"},
+		maftir:  "Numbers 29:1-6",
+		haftara: [2]string{"I Samuel 1:1-2:10"},
+	},
+	"Rosh Hashana II": {
+		weekday: []string{"Genesis 22:1-3", "Genesis 22:4-8", "Genesis 22:9-14",
+			"Genesis 22:15-19", "Genesis 22:20-24"},
+		maftir:  "Numbers 29:1-6",
+		haftara: [2]string{"Jeremiah 31:1-19"},
+	},
+	"Yom Kippur": {
+		weekday: []string{"Leviticus 16:1-6", "Leviticus 16:7-11", "Leviticus 16:12-17",
+			"Leviticus 16:18-24", "Leviticus 16:25-30", "Leviticus 16:31-34"},
+		shabbat: []string{"Leviticus 16:1-3", "Leviticus 16:4-6", "Leviticus 16:7-11",
+			"Leviticus 16:12-17", "Leviticus 16:18-24", "Leviticus 16:25-30", "Leviticus 16:31-34"},
+		maftir:  "Numbers 29:7-11",
+		haftara: [2]string{"Isaiah 57:14-58:14"},
+	},
+	"Sukkot I": {
+		weekday: sukkotAliyot,
+		shabbat: sukkotShabbatAliyot,
+		maftir:  "Numbers 29:12-16",
+		haftara: [2]string{"Zechariah 14:1-21"},
+	},
+	"Sukkot II": {
+		weekday: sukkotAliyot,
+		maftir:  "Numbers 29:12-16",
+		haftara: [2]string{"I Kings 8:2-21"},
+	},
+	"Shmini Atzeret": {
+		weekday: []string{"Deuteronomy 14:22-29", "Deuteronomy 15:1-18", "Deuteronomy 15:19-23",
+			"Deuteronomy 16:1-3", "Deuteronomy 16:4-17"},
+		shabbat: asserTeaserShabbatAliyot,
+		maftir:  "Numbers 29:35-30:1",
+		haftara: [2]string{"I Kings 8:54-66"},
+	},
+	"Simchat Torah": {
+		weekday: []string{"Deuteronomy 33:1-7", "Deuteronomy 33:8-12", "Deuteronomy 33:13-17",
+			"Deuteronomy 33:18-21", "Deuteronomy 33:22-26", "Deuteronomy 33:27-34:12",
+			"Genesis 1:1-2:3"},
+		maftir:  "Numbers 29:35-30:1",
+		haftara: [2]string{"Joshua 1:1-18", "Joshua 1:1-9"},
+	},
+	"Pesach I": {
+		weekday: []string{"Exodus 12:21-24", "Exodus 12:25-28", "Exodus 12:29-36",
+			"Exodus 12:37-42", "Exodus 12:43-51"},
+		shabbat: []string{"Exodus 12:21-24", "Exodus 12:25-28", "Exodus 12:29-32", "Exodus 12:33-36",
+			"Exodus 12:37-42", "Exodus 12:43-47", "Exodus 12:48-51"},
+		maftir:  "Numbers 28:16-25",
+		haftara: [2]string{"Joshua 3:5-7; 5:2-6:1; 6:27", "Joshua 5:2-6:1; 6:27"},
+	},
+	"Pesach II": {
+		weekday: sukkotAliyot,
+		maftir:  "Numbers 28:16-25",
+		haftara: [2]string{"II Kings 23:1-9; 23:21-25"},
+	},
+	"Pesach VII": {
+		weekday: []string{"Exodus 13:17-22", "Exodus 14:1-8", "Exodus 14:9-14",
+			"Exodus 14:15-25", "Exodus 14:26-15:26"},
+		shabbat: []string{"Exodus 13:17-22", "Exodus 14:1-4", "Exodus 14:5-8", "Exodus 14:9-14",
+			"Exodus 14:15-25", "Exodus 14:26-15:21", "Exodus 15:22-26"},
+		maftir:  "Numbers 28:19-25",
+		haftara: [2]string{"II Samuel 22:1-51"},
+	},
+	"Pesach VIII": {
+		weekday: asserTeaserAliyot,
+		shabbat: asserTeaserShabbatAliyot,
+		maftir:  "Numbers 28:19-25",
+		haftara: [2]string{"Isaiah 10:32-12:6"},
+	},
+	"Shavuot I": {
+		weekday: []string{"Exodus 19:1-6", "Exodus 19:7-13", "Exodus 19:14-19",
+			"Exodus 19:20-20:14", "Exodus 20:15-23"},
+		shabbat: []string{"Exodus 19:1-6", "Exodus 19:7-13", "Exodus 19:14-19", "Exodus 19:20-25",
+			"Exodus 20:1-14", "Exodus 20:15-18", "Exodus 20:19-23"},
+		maftir:  "Numbers 28:26-31",
+		haftara: [2]string{"Ezekiel 1:1-28; 3:12"},
+	},
+	"Shavuot II": {
+		weekday: asserTeaserAliyot,
+		shabbat: asserTeaserShabbatAliyot,
+		maftir:  "Numbers 28:26-31",
+		haftara: [2]string{"Habakkuk 2:20-3:19", "Habakkuk 3:1-19"},
+	},
+	"Purim": {
+		weekday: []string{"Exodus 17:8-10", "Exodus 17:11-13", "Exodus 17:14-16"},
+	},
+}
+
+// Weekday readings of Chol HaMoed Pesach. The fourth aliyah of each is
+// the day's offering, Numbers 28:19-25.
+var (
+	pesachShorOKesev = []string{"Leviticus 22:26-23:3", "Leviticus 23:4-14", "Leviticus 23:15-44"}
+	pesachKadesh     = []string{"Exodus 13:1-4", "Exodus 13:5-10", "Exodus 13:11-16"}
+	pesachImKesef    = []string{"Exodus 22:24-26", "Exodus 22:27-23:5", "Exodus 23:6-19"}
+	pesachPesalLecha = []string{"Exodus 34:1-3", "Exodus 34:4-17", "Exodus 34:18-26"}
+	pesachBamidbar   = []string{"Numbers 9:1-5", "Numbers 9:6-8", "Numbers 9:9-14"}
+)
+
+// Maftir for the four special Shabbatot before Pesach
+var specialMaftir = map[string]string{
+	"Shabbat Shekalim":  "Exodus 30:11-16",
+	"Shabbat Zachor":    "Deuteronomy 25:17-19",
+	"Shabbat Parah":     "Numbers 19:1-22",
+	"Shabbat HaChodesh": "Exodus 12:1-20",
+}
+
+// parseAliyah parses a string such as "Exodus 12:21-24".
+func parseAliyah(s string) Aliyah {
+	space := strings.LastIndex(s, " ")
+	return newAliyah(s[:space], s[space+1:])
+}
+
+func parseAliyot(aliyot []string) []Aliyah {
+	result := make([]Aliyah, len(aliyot))
+	for i, s := range aliyot {
+		result[i] = parseAliyah(s)
+	}
+	return result
+}
+
+func (f festival) reading(hd hdate.HDate, minhag Minhag) HolidayReading {
+	aliyot := f.weekday
+	if hd.Weekday() == time.Saturday && f.shabbat != nil {
+		aliyot = f.shabbat
+	}
+	r := HolidayReading{Date: hd, Aliyot: parseAliyot(aliyot)}
+	if f.maftir != "" {
+		r.Maftir = parseAliyah(f.maftir)
+	}
+	if f.haftara[0] != "" {
+		r.Haftarah = selectMinhag(f.haftara, minhag)
+	}
+	return r
+}
+
+// sukkotOffering returns the offering for day (1-7) of Sukkot
+// through day last, in Numbers 29.
+func sukkotOffering(day, last int) Aliyah {
+	begin := Verse{Chapter: 29, Verse: 12}
+	if day > 1 {
+		begin.Verse = 17 + 3*(day-2)
+	}
+	end := Verse{Chapter: 29, Verse: 16}
+	if last > 1 {
+		end.Verse = 19 + 3*(last-2)
+	}
+	return Aliyah{Book: Numbers, Begin: begin, End: end, Verses: countVerses(Numbers, begin, end)}
+}
+
+// sukkotCholHaMoed returns the reading for day (2-7) of Sukkot.
+// In the Diaspora the first three aliyot read the offerings of the
+// day in doubt and the two days after it, and the fourth reads both
+// days in doubt; on Hoshana Raba, days 5-7 and then days 6 and 7.
+// In Israel, where there is no doubt, all four aliyot read the day's
+// own offering.
+func sukkotCholHaMoed(hd hdate.HDate, day int, il bool) HolidayReading {
+	r := HolidayReading{Date: hd}
+	if hd.Weekday() == time.Saturday {
+		r.Aliyot = parseAliyot(shabbatCholHaMoedAliyot)
+		if il {
+			r.Maftir = sukkotOffering(day, day)
+		} else {
+			r.Maftir = sukkotOffering(day-1, day)
+		}
+		r.Haftarah = newHaftarah("Ezekiel 38:18-39:16")
+		return r
+	}
+	if il {
+		offering := sukkotOffering(day, day)
+		r.Aliyot = []Aliyah{offering, offering, offering, offering}
+		return r
+	}
+	first := day - 1
+	if day == 7 {
+		first = 5
+	}
+	r.Aliyot = []Aliyah{
+		sukkotOffering(first, first),
+		sukkotOffering(first+1, first+1),
+		sukkotOffering(first+2, first+2),
+		sukkotOffering(day-1, day),
+	}
+	return r
+}
+
+// pesachCholHaMoed returns the reading for Chol HaMoed Pesach on hd.
+// The weekday readings are read in order; when Shabbat falls during
+// Chol HaMoed its reading (Exodus 33:12-34:26) replaces Exodus 34.
+func pesachCholHaMoed(hd hdate.HDate, il bool) HolidayReading {
+	r := HolidayReading{Date: hd, Maftir: parseAliyah("Numbers 28:19-25")}
+	if hd.Weekday() == time.Saturday {
+		r.Aliyot = parseAliyot(shabbatCholHaMoedAliyot)
+		r.Haftarah = newHaftarah("Ezekiel 37:1-14")
+		return r
+	}
+	year := hd.Year()
+	first := hdate.ToRD(year, hdate.Nisan, 17)
+	readings := [][]string{pesachKadesh, pesachImKesef, pesachPesalLecha, pesachBamidbar}
+	if il {
+		first--
+		readings = append([][]string{pesachShorOKesev}, readings...)
+	}
+	last := hdate.ToRD(year, hdate.Nisan, 20)
+	if hdate.DayOnOrBefore(time.Saturday, last) >= first {
+		readings = append(readings[:len(readings)-2], pesachBamidbar)
+	}
+	idx := 0
+	for abs := first; abs < hd.Abs(); abs++ {
+		if hdate.FromRD(abs).Weekday() != time.Saturday {
+			idx++
+		}
+	}
+	r.Aliyot = append(parseAliyot(readings[idx]), r.Maftir)
+	r.Maftir = Aliyah{}
+	return r
+}
+
+// chanukahPrince returns the offering of the nth prince (1-12) in
+// Numbers 7, or half of it (part 1 or 2; 0 for the whole).
+func chanukahPrince(n, part int) Aliyah {
+	first := 12 + 6*(n-1)
+	begin := Verse{Chapter: 7, Verse: first}
+	end := Verse{Chapter: 7, Verse: first + 5}
+	switch part {
+	case 1:
+		end.Verse = first + 2
+	case 2:
+		begin.Verse = first + 3
+	}
+	return Aliyah{Book: Numbers, Begin: begin, End: end, Verses: countVerses(Numbers, begin, end)}
+}
+
+// chanukahMaftir returns the day's Chanukah reading read as a single
+// aliyah on Shabbat or Rosh Chodesh.
+func chanukahMaftir(day int) Aliyah {
+	switch day {
+	case 1:
+		return parseAliyah("Numbers 7:1-17")
+	case 8:
+		return parseAliyah("Numbers 7:54-8:4")
+	}
+	return chanukahPrince(day, 0)
+}
+
+// chanukah returns the weekday reading for day (1-8) of Chanukah.
+// Ashkenazim read the next day's prince for the third aliyah;
+// Sephardim repeat the day's own.
+func chanukah(hd hdate.HDate, day int, minhag Minhag) HolidayReading {
+	r := HolidayReading{Date: hd}
+	if hd.Day() == 30 || hd.Day() == 1 {
+		r.Aliyot = append(parseAliyot([]string{"Numbers 28:1-5", "Numbers 28:6-10", "Numbers 28:11-15"}),
+			chanukahMaftir(day))
+		return r
+	}
+	switch day {
+	case 1:
+		r.Aliyot = parseAliyot([]string{"Numbers 7:1-11", "Numbers 7:12-14", "Numbers 7:15-17"})
+	case 8:
+		r.Aliyot = parseAliyot([]string{"Numbers 7:54-56", "Numbers 7:57-59", "Numbers 7:60-8:4"})
+	default:
+		third := chanukahPrince(day+1, 0)
+		if minhag == Sephardi {
+			third = chanukahPrince(day, 0)
+		}
+		r.Aliyot = []Aliyah{chanukahPrince(day, 1), chanukahPrince(day, 2), third}
+	}
+	return r
+}
+
+// chanukahDay returns the day (1-8) of Chanukah on hd, or 0.
+func chanukahDay(hd hdate.HDate) int {
+	day := hd.Abs() - hdate.ToRD(hd.Year(), hdate.Kislev, 25) + 1
+	if day < 1 || day > 8 {
+		return 0
+	}
+	return int(day)
+}
+
+// shabbatReading returns the additions to the weekly parsha on a
+// Shabbat that is Rosh Chodesh, Chanukah or a special Shabbat.
+// The maftir is, in order of precedence, Chanukah, one of the four
+// parshiyot, or Rosh Chodesh; a displaced Rosh Chodesh reading is
+// read as the seventh aliyah.
+func shabbatReading(hd hdate.HDate, il bool, minhag Minhag) (HolidayReading, error) {
+	haftarah, _, err := LookupHaftarah(hd, il, minhag)
+	if err != nil {
+		return HolidayReading{}, err
+	}
+	r := HolidayReading{Date: hd, Parsha: true, Aliyot: []Aliyah{}, Haftarah: haftarah}
+	roshChodesh := hd.Day() == 30 || (hd.Day() == 1 && hd.Month() != hdate.Tishrei)
+	special := specialShabbat(hd)
+	if day := chanukahDay(hd); day != 0 {
+		r.Maftir = chanukahMaftir(day)
+	} else if maftir, ok := specialMaftir[special]; ok {
+		r.Maftir = parseAliyah(maftir)
+	} else if roshChodesh {
+		r.Maftir = parseAliyah("Numbers 28:9-15")
+		return r, nil
+	}
+	if roshChodesh {
+		r.Aliyot = append(r.Aliyot, parseAliyah("Numbers 28:9-15"))
+	}
+	return r, nil
+}
+
+func fastDay(hd hdate.HDate, minhag Minhag) HolidayReading {
+	mincha := HolidayReading{Date: hd, Aliyot: parseAliyot(fastAliyot)}
+	if minhag == Ashkenazi {
+		mincha.Haftarah = newHaftarah("Isaiah 55:6-56:8")
+	}
+	return HolidayReading{Date: hd, Aliyot: parseAliyot(fastAliyot), Mincha: &mincha}
+}
+
+func tishaBav(hd hdate.HDate, minhag Minhag) HolidayReading {
+	mincha := HolidayReading{
+		Date:     hd,
+		Aliyot:   parseAliyot(fastAliyot),
+		Haftarah: selectMinhag([2]string{"Isaiah 55:6-56:8", "Hosea 14:2-10; Micah 7:18-20"}, minhag),
+	}
+	return HolidayReading{
+		Date:     hd,
+		Aliyot:   parseAliyot([]string{"Deuteronomy 4:25-29", "Deuteronomy 4:30-35", "Deuteronomy 4:36-40"}),
+		Haftarah: newHaftarah("Jeremiah 8:13-9:23"),
+		Mincha:   &mincha,
+	}
+}
+
+/*
+LookupHoliday returns the Torah reading for ev, a holiday generated
+by hebcal according to the Israel (il=true) or Diaspora schedule,
+using the haftarot of the given minhag.
+
+Readings are provided for Rosh Hashana, Yom Kippur, Sukkot, Shmini
+Atzeret, Simchat Torah, Pesach, Shavuot, Chol HaMoed, Chanukah, Rosh
+Chodesh, Purim, the public fasts and Tish'a B'Av, and for the special
+Shabbatot. The reading reflects everything that happens on ev's date:
+on Rosh Chodesh Tevet, for example, both the Rosh Chodesh and
+Chanukah events return the combined reading, and on Shabbat the
+weekday reading is replaced by the Shabbat one.
+
+Returns an error for events without a Torah reading of their own,
+such as Erev Pesach or Tu BiShvat.
+*/
+func LookupHoliday(ev event.HolidayEvent, il bool, minhag Minhag) (HolidayReading, error) {
+	hd := hdate.FromRD(ev.Date.Abs())
+	shabbat := hd.Weekday() == time.Saturday
+	desc := ev.Desc
+	switch {
+	case (ev.Flags & event.CHOL_HAMOED) != 0:
+		if hd.Month() == hdate.Tishrei {
+			return sukkotCholHaMoed(hd, hd.Day()-14, il), nil
+		}
+		return pesachCholHaMoed(hd, il), nil
+	case strings.HasPrefix(desc, "Chanukah") && ev.ChanukahDay >= 1,
+		strings.HasPrefix(desc, "Rosh Chodesh ") && chanukahDay(hd) != 0:
+		if shabbat {
+			return shabbatReading(hd, il, minhag)
+		}
+		return chanukah(hd, chanukahDay(hd), minhag), nil
+	case strings.HasPrefix(desc, "Rosh Chodesh "):
+		if shabbat {
+			return shabbatReading(hd, il, minhag)
+		}
+		aliyot := []string{"Numbers 28:1-3", "Numbers 28:3-5", "Numbers 28:6-10", "Numbers 28:11-15"}
+		return HolidayReading{Date: hd, Aliyot: parseAliyot(aliyot)}, nil
+	case (ev.Flags & event.SPECIAL_SHABBAT) != 0:
+		return shabbatReading(hd, il, minhag)
+	case desc == "Tzom Gedaliah", desc == "Asara B'Tevet", desc == "Ta'anit Esther",
+		desc == "Tzom Tammuz":
+		return fastDay(hd, minhag), nil
+	case desc == "Tish'a B'Av", desc == "Tish'a B'Av (observed)":
+		return tishaBav(hd, minhag), nil
+	case desc == "Yom Kippur":
+		r := festivals[desc].reading(hd, minhag)
+		mincha := HolidayReading{
+			Date:     hd,
+			Aliyot:   parseAliyot([]string{"Leviticus 18:1-5", "Leviticus 18:6-21", "Leviticus 18:22-30"}),
+			Haftarah: newHaftarah("Jonah 1:1-4:11; Micah 7:18-20"),
+		}
+		r.Mincha = &mincha
+		return r, nil
+	case desc == "Rosh Hashana "+strconv.Itoa(hd.Year()):
+		desc = "Rosh Hashana I"
+	case desc == "Shavuot":
+		desc = "Shavuot I"
+	case desc == "Shmini Atzeret" && il:
+		// Israel celebrates Simchat Torah on Shmini Atzeret
+		desc = "Simchat Torah"
+	}
+	f, ok := festivals[desc]
+	if !ok {
+		return HolidayReading{}, errors.New("no Torah reading for " + ev.Desc)
+	}
+	return f.reading(hd, minhag), nil
+}
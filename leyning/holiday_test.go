@@ -0,0 +1,209 @@
+package leyning_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+	"github.com/MaxBGreenberg/hebcal-go/leyning"
+	"github.com/stretchr/testify/assert"
+)
+
+func holidayAliyot(r leyning.HolidayReading) []string {
+	result := make([]string, len(r.Aliyot))
+	for i, a := range r.Aliyot {
+		result[i] = a.String()
+	}
+	return result
+}
+
+func TestLookupHolidayYomTov(t *testing.T) {
+	assert := assert.New(t)
+	ev := event.HolidayEvent{Date: hdate.New(5783, hdate.Nisan, 15), Desc: "Pesach I", Flags: event.CHAG}
+	r, err := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Nil(err)
+	assert.Equal(false, r.Parsha)
+	assert.Equal(5, len(r.Aliyot))
+	assert.Equal("Exodus 12:21-24", r.Aliyot[0].String())
+	assert.Equal("Numbers 28:16-25", r.Maftir.String())
+	assert.Equal("Joshua 3:5-7; 5:2-6:1; 6:27", r.Haftarah.String())
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Sephardi)
+	assert.Equal("Joshua 5:2-6:1; 6:27", r.Haftarah.String())
+
+	// Shavuot II on Shabbat reads from Deuteronomy 14:22
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Sivan, 7), Desc: "Shavuot II", Flags: event.CHAG}
+	r, err = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Nil(err)
+	assert.Equal(7, len(r.Aliyot))
+	assert.Equal("Deuteronomy 14:22-29", r.Aliyot[0].String())
+	assert.Equal("Habakkuk 2:20-3:19", r.Haftarah.String())
+
+	// In Israel, Shmini Atzeret is also Simchat Torah
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Tishrei, 22), Desc: "Shmini Atzeret", Flags: event.CHAG}
+	r, _ = leyning.LookupHoliday(ev, true, leyning.Ashkenazi)
+	assert.Equal("Genesis 1:1-2:3", r.Aliyot[6].String())
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal("Deuteronomy 14:22-29", r.Aliyot[0].String())
+
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Tishrei, 10), Desc: "Yom Kippur", Flags: event.CHAG}
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(6, len(r.Aliyot))
+	assert.Equal("Jonah 1:1-4:11; Micah 7:18-20", r.Mincha.Haftarah.String())
+}
+
+func TestLookupHolidayCholHaMoed(t *testing.T) {
+	hd := hdate.New(5783, hdate.Tishrei, 17)
+	ev := event.HolidayEvent{Date: hd, Desc: "Sukkot III (CH''M)", Flags: event.CHOL_HAMOED, CholHaMoedDay: 1}
+	r, err := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"Numbers 29:17-19",
+		"Numbers 29:20-22",
+		"Numbers 29:23-25",
+		"Numbers 29:17-22",
+	}, holidayAliyot(r))
+	ev.CholHaMoedDay = 2
+	r, _ = leyning.LookupHoliday(ev, true, leyning.Ashkenazi)
+	assert.Equal(t, []string{
+		"Numbers 29:20-22",
+		"Numbers 29:20-22",
+		"Numbers 29:20-22",
+		"Numbers 29:20-22",
+	}, holidayAliyot(r))
+
+	// Israel reads only the day's offering, through Hoshana Raba
+	ev = event.HolidayEvent{Date: hdate.New(5784, hdate.Tishrei, 20), Desc: "Sukkot VI (CH''M)", Flags: event.CHOL_HAMOED}
+	r, _ = leyning.LookupHoliday(ev, true, leyning.Ashkenazi)
+	assert.Equal(t, []string{
+		"Numbers 29:29-31",
+		"Numbers 29:29-31",
+		"Numbers 29:29-31",
+		"Numbers 29:29-31",
+	}, holidayAliyot(r))
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, []string{
+		"Numbers 29:26-28",
+		"Numbers 29:29-31",
+		"Numbers 29:32-34",
+		"Numbers 29:26-31",
+	}, holidayAliyot(r))
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Tishrei, 21), Desc: "Sukkot VII (Hoshana Raba)", Flags: event.CHOL_HAMOED}
+	r, _ = leyning.LookupHoliday(ev, true, leyning.Ashkenazi)
+	assert.Equal(t, []string{
+		"Numbers 29:32-34",
+		"Numbers 29:32-34",
+		"Numbers 29:32-34",
+		"Numbers 29:32-34",
+	}, holidayAliyot(r))
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, []string{
+		"Numbers 29:26-28",
+		"Numbers 29:29-31",
+		"Numbers 29:32-34",
+		"Numbers 29:29-34",
+	}, holidayAliyot(r))
+
+	// Shabbat Chol HaMoed Sukkot
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Tishrei, 20), Desc: "Sukkot VI (CH''M)", Flags: event.CHOL_HAMOED}
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, 7, len(r.Aliyot))
+	assert.Equal(t, "Numbers 29:26-31", r.Maftir.String())
+	r, _ = leyning.LookupHoliday(ev, true, leyning.Ashkenazi)
+	assert.Equal(t, "Numbers 29:29-31", r.Maftir.String())
+	assert.Equal(t, "Ezekiel 38:18-39:16", r.Haftarah.String())
+
+	// Shabbat Chol HaMoed Pesach on 17 Nisan displaces Exodus 34
+	expected := []string{"Exodus 13:1-4", "Exodus 22:24-26", "Numbers 9:1-5"}
+	for i, day := range []int{18, 19, 20} {
+		ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Nisan, day), Flags: event.CHOL_HAMOED}
+		r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+		assert.Equal(t, expected[i], r.Aliyot[0].String())
+		assert.Equal(t, "Numbers 28:19-25", r.Aliyot[3].String())
+	}
+}
+
+func TestLookupHolidayChanukah(t *testing.T) {
+	ev := event.HolidayEvent{Date: hdate.New(5783, hdate.Kislev, 26), Desc: "Chanukah: 3 Candles", ChanukahDay: 2}
+	r, _ := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, []string{"Numbers 7:18-20", "Numbers 7:21-23", "Numbers 7:24-29"}, holidayAliyot(r))
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Sephardi)
+	assert.Equal(t, []string{"Numbers 7:18-20", "Numbers 7:21-23", "Numbers 7:18-23"}, holidayAliyot(r))
+
+	// Rosh Chodesh Tevet on a weekday
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Tevet, 1), Desc: "Rosh Chodesh Tevet"}
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, []string{
+		"Numbers 28:1-5",
+		"Numbers 28:6-10",
+		"Numbers 28:11-15",
+		"Numbers 7:48-53",
+	}, holidayAliyot(r))
+
+	// Shabbat Chanukah and Rosh Chodesh
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Kislev, 30), Desc: "Chanukah: 7 Candles", ChanukahDay: 6}
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, true, r.Parsha)
+	assert.Equal(t, []string{"Numbers 28:9-15"}, holidayAliyot(r))
+	assert.Equal(t, "Numbers 7:42-47", r.Maftir.String())
+	assert.Equal(t, "Zechariah 2:14-4:7", r.Haftarah.String())
+}
+
+func TestLookupHolidayShabbat(t *testing.T) {
+	ev := event.HolidayEvent{Date: hdate.New(5783, hdate.Iyyar, 1), Desc: "Rosh Chodesh Iyyar"}
+	r, _ := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, true, r.Parsha)
+	assert.Equal(t, 0, len(r.Aliyot))
+	assert.Equal(t, "Numbers 28:9-15", r.Maftir.String())
+	assert.Equal(t, "Isaiah 66:1-24", r.Haftarah.String())
+
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Adar1, 11), Desc: "Shabbat Zachor", Flags: event.SPECIAL_SHABBAT}
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Sephardi)
+	assert.Equal(t, "Deuteronomy 25:17-19", r.Maftir.String())
+	assert.Equal(t, "I Samuel 15:1-34", r.Haftarah.String())
+}
+
+func TestLookupHolidayFast(t *testing.T) {
+	ev := event.HolidayEvent{Date: hdate.New(5783, hdate.Tevet, 10), Desc: "Asara B'Tevet", Flags: event.MINOR_FAST}
+	r, _ := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, []string{"Exodus 32:11-14", "Exodus 34:1-3", "Exodus 34:4-10"}, holidayAliyot(r))
+	assert.Nil(t, r.Haftarah)
+	assert.Equal(t, "Isaiah 55:6-56:8", r.Mincha.Haftarah.String())
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Sephardi)
+	assert.Nil(t, r.Mincha.Haftarah)
+
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Av, 9), Desc: "Tish'a B'Av", Flags: event.MAJOR_FAST}
+	r, _ = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.Equal(t, "Deuteronomy 4:25-29", r.Aliyot[0].String())
+	assert.Equal(t, "Jeremiah 8:13-9:23", r.Haftarah.String())
+}
+
+func TestLookupHolidayNoReading(t *testing.T) {
+	ev := event.HolidayEvent{Date: hdate.New(5783, hdate.Shvat, 15), Desc: "Tu BiShvat", Flags: event.MINOR_HOLIDAY}
+	_, err := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.NotNil(t, err)
+	ev = event.HolidayEvent{Date: hdate.New(5783, hdate.Kislev, 24), Desc: "Chanukah: 1 Candle", Flags: event.EREV}
+	_, err = leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	assert.NotNil(t, err)
+}
+
+func ExampleLookupHoliday() {
+	ev := event.HolidayEvent{
+		Date:  hdate.FromGregorian(2023, time.July, 27),
+		Desc:  "Tish'a B'Av",
+		Flags: event.MAJOR_FAST,
+	}
+	r, _ := leyning.LookupHoliday(ev, false, leyning.Ashkenazi)
+	for i, aliyah := range r.Aliyot {
+		fmt.Println(i+1, aliyah)
+	}
+	fmt.Println("Haftarah:", r.Haftarah)
+	fmt.Println("Mincha haftarah:", r.Mincha.Haftarah)
+	// Output:
+	// 1 Deuteronomy 4:25-29
+	// 2 Deuteronomy 4:30-35
+	// 3 Deuteronomy 4:36-40
+	// Haftarah: Jeremiah 8:13-9:23
+	// Mincha haftarah: Isaiah 55:6-56:8
+}
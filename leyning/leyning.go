@@ -0,0 +1,105 @@
+// Hebcal's leyning package provides the Torah reading (leyning)
+// for each week: the book, chapter and verse ranges of the seven
+// aliyot and maftir of the weekly parsha, and the haftarah, as well
+// as the special readings for holidays, Rosh Chodesh and fast days.
+package leyning
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Verse identifies a chapter and verse within a book.
+type Verse struct {
+	Chapter int
+	Verse   int
+}
+
+// Less reports whether v comes before other in the same book.
+func (v Verse) Less(other Verse) bool {
+	if v.Chapter != other.Chapter {
+		return v.Chapter < other.Chapter
+	}
+	return v.Verse < other.Verse
+}
+
+// Returns a string representation such as "2:3"
+func (v Verse) String() string {
+	return strconv.Itoa(v.Chapter) + ":" + strconv.Itoa(v.Verse)
+}
+
+// Aliyah is a contiguous range of verses within a single book,
+// e.g. one aliyah, the maftir, or an entire parsha.
+type Aliyah struct {
+	Book   string // e.g. "Genesis"
+	Begin  Verse  // first verse read
+	End    Verse  // last verse read (inclusive)
+	Verses int    // number of verses from Begin through End
+}
+
+// Returns a string representation such as "Genesis 1:1-2:3"
+// or "Genesis 2:4-19"
+func (a Aliyah) String() string {
+	return a.Book + " " + formatRange(a.Begin, a.End)
+}
+
+// formatRange returns "1:1-2:3", "2:4-19", or "3:4" for a single verse.
+func formatRange(begin, end Verse) string {
+	if begin == end {
+		return begin.String()
+	}
+	s := begin.String() + "-"
+	if begin.Chapter == end.Chapter {
+		return s + strconv.Itoa(end.Verse)
+	}
+	return s + end.String()
+}
+
+// newAliyah returns the Aliyah for a range such as "1:1-2:3" or "2:4-19".
+// It panics if the range is malformed or outside of book.
+func newAliyah(book, s string) Aliyah {
+	begin, end := parseRange(s)
+	verses := countVerses(book, begin, end)
+	if verses == 0 {
+		panic("invalid verse range " + book + " " + s)
+	}
+	return Aliyah{Book: book, Begin: begin, End: end, Verses: verses}
+}
+
+// parseRange parses a range such as "1:1-2:3", "2:4-19" or "3:4".
+func parseRange(s string) (Verse, Verse) {
+	parts := strings.SplitN(s, "-", 2)
+	begin := parseVerse(parts[0], 0)
+	if len(parts) == 1 {
+		return begin, begin
+	}
+	return begin, parseVerse(parts[1], begin.Chapter)
+}
+
+// parseVerse parses "chapter:verse", or a bare verse number
+// within chapter.
+func parseVerse(s string, chapter int) Verse {
+	cv := strings.Split(s, ":")
+	if len(cv) == 2 {
+		chapter, _ = strconv.Atoi(cv[0])
+		s = cv[1]
+	}
+	verse, _ := strconv.Atoi(s)
+	return Verse{Chapter: chapter, Verse: verse}
+}
@@ -0,0 +1,153 @@
+package leyning_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/leyning"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+	"github.com/stretchr/testify/assert"
+)
+
+func aliyotStrings(r leyning.Reading) []string {
+	result := make([]string, len(r.Aliyot))
+	for i, a := range r.Aliyot {
+		result[i] = a.String()
+	}
+	return result
+}
+
+func TestParshaReading(t *testing.T) {
+	assert := assert.New(t)
+	r, err := leyning.ParshaReading(sedra.Parsha{Name: []string{"Bereshit"}, Num: []int{1}})
+	assert.Nil(err)
+	assert.Equal([]string{"Bereshit"}, r.Parsha)
+	assert.Equal("Genesis 1:1-6:8", r.Torah.String())
+	assert.Equal(146, r.Torah.Verses)
+	assert.Equal([]string{
+		"Genesis 1:1-2:3",
+		"Genesis 2:4-19",
+		"Genesis 2:20-3:21",
+		"Genesis 3:22-4:18",
+		"Genesis 4:19-22",
+		"Genesis 4:23-5:24",
+		"Genesis 5:25-6:8",
+	}, aliyotStrings(r))
+	assert.Equal(34, r.Aliyot[0].Verses)
+	assert.Equal(leyning.Aliyah{
+		Book:   leyning.Genesis,
+		Begin:  leyning.Verse{Chapter: 6, Verse: 5},
+		End:    leyning.Verse{Chapter: 6, Verse: 8},
+		Verses: 4,
+	}, r.Maftir)
+
+	_, err = leyning.ParshaReading(sedra.Parsha{Chag: true})
+	assert.NotNil(err)
+}
+
+func TestParshaReadingDoubled(t *testing.T) {
+	assert := assert.New(t)
+	r, err := leyning.ParshaReading(sedra.Parsha{Name: []string{"Matot", "Masei"}, Num: []int{42, 43}})
+	assert.Nil(err)
+	assert.Equal([]string{"Matot", "Masei"}, r.Parsha)
+	assert.Equal("Numbers 30:2-36:13", r.Torah.String())
+	assert.Equal("Numbers 36:11-13", r.Maftir.String())
+
+	r, err = leyning.ParshaReadingByName("Lech-Lecha")
+	assert.Nil(err)
+	assert.Equal([]string{"Lech-Lecha"}, r.Parsha)
+}
+
+// Every doubled reading spans exactly its two parshiyot
+func TestDoubledParshiyot(t *testing.T) {
+	doubles := [][2]string{
+		{"Vayakhel", "Pekudei"},
+		{"Tazria", "Metzora"},
+		{"Achrei Mot", "Kedoshim"},
+		{"Behar", "Bechukotai"},
+		{"Chukat", "Balak"},
+		{"Matot", "Masei"},
+		{"Nitzavim", "Vayeilech"},
+	}
+	for _, pair := range doubles {
+		r1, _ := leyning.ParshaReadingByName(pair[0])
+		r2, _ := leyning.ParshaReadingByName(pair[1])
+		r, err := leyning.ParshaReadingByName(pair[0] + "-" + pair[1])
+		assert.Nil(t, err)
+		assert.Equal(t, r1.Torah.Begin, r.Torah.Begin, pair[0])
+		assert.Equal(t, r2.Torah.End, r.Torah.End, pair[1])
+		assert.Equal(t, r1.Torah.Verses+r2.Torah.Verses, r.Torah.Verses, pair[0])
+		assert.Equal(t, r2.Maftir, r.Maftir, pair[1])
+	}
+}
+
+// The aliyot of consecutive parshiyot cover the Torah without gaps
+func TestAliyotContiguous(t *testing.T) {
+	var prev leyning.Aliyah
+	names := []string{"Bereshit", "Noach", "Lech-Lecha", "Vayera", "Chayei Sara", "Toldot",
+		"Vayetzei", "Vayishlach", "Vayeshev", "Miketz", "Vayigash", "Vayechi", "Shemot",
+		"Vaera", "Bo", "Beshalach", "Yitro", "Mishpatim", "Terumah", "Tetzaveh", "Ki Tisa",
+		"Vayakhel", "Pekudei", "Vayikra", "Tzav", "Shmini", "Tazria", "Metzora", "Achrei Mot",
+		"Kedoshim", "Emor", "Behar", "Bechukotai", "Bamidbar", "Nasso", "Beha'alotcha",
+		"Sh'lach", "Korach", "Chukat", "Balak", "Pinchas", "Matot", "Masei", "Devarim",
+		"Vaetchanan", "Eikev", "Re'eh", "Shoftim", "Ki Teitzei", "Ki Tavo", "Nitzavim",
+		"Vayeilech", "Ha'azinu", "Vezot Haberakhah"}
+	for _, name := range names {
+		r, err := leyning.ParshaReadingByName(name)
+		assert.Nil(t, err, name)
+		total := 0
+		for _, a := range r.Aliyot {
+			if a.Book == prev.Book {
+				next := leyning.Verse{Chapter: prev.End.Chapter, Verse: prev.End.Verse + 1}
+				if a.Begin != next {
+					next = leyning.Verse{Chapter: prev.End.Chapter + 1, Verse: 1}
+				}
+				assert.Equal(t, next, a.Begin, a.String())
+			} else {
+				assert.Equal(t, leyning.Verse{Chapter: 1, Verse: 1}, a.Begin, a.String())
+			}
+			total += a.Verses
+			prev = a
+		}
+		assert.Equal(t, r.Torah.Verses, total, name)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	assert := assert.New(t)
+	// 8th day of Pesach on Shabbat; Israel reads Achrei Mot a week ahead
+	hd := hdate.FromGregorian(2022, time.April, 23)
+	_, err := leyning.Lookup(hd, false)
+	assert.NotNil(err)
+	r, err := leyning.Lookup(hd, true)
+	assert.Nil(err)
+	assert.Equal([]string{"Achrei Mot"}, r.Parsha)
+
+	r, err = leyning.Lookup(hdate.FromGregorian(2022, time.April, 27), false)
+	assert.Nil(err)
+	assert.Equal([]string{"Achrei Mot"}, r.Parsha)
+	r, err = leyning.Lookup(hdate.FromGregorian(2022, time.April, 27), true)
+	assert.Nil(err)
+	assert.Equal([]string{"Kedoshim"}, r.Parsha)
+}
+
+func ExampleLookup() {
+	r, _ := leyning.Lookup(hdate.FromGregorian(2023, time.July, 15), false)
+	fmt.Println(r.Torah, r.Torah.Verses)
+	for i, aliyah := range r.Aliyot {
+		fmt.Println(i+1, aliyah)
+	}
+	fmt.Println("M", r.Maftir)
+	// Output:
+	// Numbers 30:2-36:13 244
+	// 1 Numbers 30:2-31:12
+	// 2 Numbers 31:13-41
+	// 3 Numbers 31:42-32:19
+	// 4 Numbers 32:20-33:49
+	// 5 Numbers 33:50-34:15
+	// 6 Numbers 34:16-35:8
+	// 7 Numbers 35:9-36:13
+	// M Numbers 36:11-13
+}
@@ -0,0 +1,151 @@
+package leyning
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+)
+
+// The three aliyot read on Monday, Thursday and Shabbat Mincha from
+// the start of each parsha. The divisions are fixed by tradition and
+// don't always fall within the first aliyah of the Shabbat reading.
+var weekdayData = map[string][3]string{
+	"Bereshit":    {"1:1-5", "1:6-8", "1:9-13"},
+	"Noach":       {"6:9-16", "6:17-19", "6:20-22"},
+	"Lech-Lecha":  {"12:1-3", "12:4-9", "12:10-13"},
+	"Vayera":      {"18:1-5", "18:6-8", "18:9-14"},
+	"Chayei Sara": {"23:1-7", "23:8-12", "23:13-16"},
+	"Toldot":      {"25:19-22", "25:23-26", "25:27-26:5"},
+	"Vayetzei":    {"28:10-12", "28:13-17", "28:18-22"},
+	"Vayishlach":  {"32:4-6", "32:7-9", "32:10-13"},
+	"Vayeshev":    {"37:1-3", "37:4-7", "37:8-11"},
+	"Miketz":      {"41:1-4", "41:5-7", "41:8-14"},
+	"Vayigash":    {"44:18-20", "44:21-24", "44:25-30"},
+	"Vayechi":     {"47:28-31", "48:1-3", "48:4-9"},
+
+	"Shemot":    {"1:1-7", "1:8-12", "1:13-17"},
+	"Vaera":     {"6:2-5", "6:6-9", "6:10-13"},
+	"Bo":        {"10:1-3", "10:4-6", "10:7-11"},
+	"Beshalach": {"13:17-22", "14:1-4", "14:5-8"},
+	"Yitro":     {"18:1-4", "18:5-8", "18:9-12"},
+	"Mishpatim": {"21:1-3", "21:4-6", "21:7-11"},
+	"Terumah":   {"25:1-5", "25:6-9", "25:10-16"},
+	"Tetzaveh":  {"27:20-21", "28:1-5", "28:6-12"},
+	"Ki Tisa":   {"30:11-13", "30:14-16", "30:17-21"},
+	"Vayakhel":  {"35:1-3", "35:4-10", "35:11-20"},
+	"Pekudei":   {"38:21-23", "38:24-27", "38:28-39:1"},
+
+	"Vayikra":    {"1:1-4", "1:5-9", "1:10-13"},
+	"Tzav":       {"6:1-3", "6:4-6", "6:7-11"},
+	"Shmini":     {"9:1-6", "9:7-10", "9:11-16"},
+	"Tazria":     {"12:1-4", "12:5-8", "13:1-5"},
+	"Metzora":    {"14:1-5", "14:6-9", "14:10-12"},
+	"Achrei Mot": {"16:1-6", "16:7-11", "16:12-17"},
+	"Kedoshim":   {"19:1-4", "19:5-10", "19:11-14"},
+	"Emor":       {"21:1-6", "21:7-12", "21:13-15"},
+	"Behar":      {"25:1-3", "25:4-7", "25:8-13"},
+	"Bechukotai": {"26:3-5", "26:6-9", "26:10-13"},
+
+	"Bamidbar":     {"1:1-4", "1:5-16", "1:17-19"},
+	"Nasso":        {"4:21-24", "4:25-28", "4:29-33"},
+	"Beha'alotcha": {"8:1-4", "8:5-9", "8:10-14"},
+	"Sh'lach":      {"13:1-3", "13:4-16", "13:17-20"},
+	"Korach":       {"16:1-3", "16:4-7", "16:8-13"},
+	"Chukat":       {"19:1-6", "19:7-9", "19:10-17"},
+	"Balak":        {"22:2-4", "22:5-7", "22:8-12"},
+	"Pinchas":      {"25:10-12", "25:13-15", "25:16-26:4"},
+	"Matot":        {"30:2-9", "30:10-13", "30:14-17"},
+	"Masei":        {"33:1-3", "33:4-6", "33:7-10"},
+
+	"Devarim":          {"1:1-3", "1:4-7", "1:8-11"},
+	"Vaetchanan":       {"3:23-25", "3:26-4:4", "4:5-8"},
+	"Eikev":            {"7:12-21", "7:22-8:3", "8:4-10"},
+	"Re'eh":            {"11:26-31", "11:32-12:5", "12:6-10"},
+	"Shoftim":          {"16:18-20", "16:21-17:10", "17:11-13"},
+	"Ki Teitzei":       {"21:10-14", "21:15-17", "21:18-21"},
+	"Ki Tavo":          {"26:1-3", "26:4-11", "26:12-15"},
+	"Nitzavim":         {"29:9-11", "29:12-14", "29:15-28"},
+	"Vayeilech":        {"31:1-3", "31:4-6", "31:7-13"},
+	"Ha'azinu":         {"32:1-3", "32:4-6", "32:7-12"},
+	"Vezot Haberakhah": {"33:1-7", "33:8-12", "33:13-17"},
+}
+
+// upcomingParsha returns the name of the next parsha read after hd,
+// starting with the Shabbat morning reading on saturday. Doubled
+// parshiyot return the first of the two. Before Simchat Torah, the next
+// parsha after Ha'azinu is Vezot Haberakhah.
+func upcomingParsha(hd, saturday hdate.HDate, il bool) string {
+	abs := saturday.Abs()
+	for {
+		year := hdate.FromRD(abs).Year()
+		sedraYear := sedra.New(year, il)
+		parsha := sedraYear.LookupByRD(abs)
+		if !parsha.Chag {
+			simchatTorah := 23
+			if il {
+				simchatTorah = 22
+			}
+			if parsha.Num[0] == 1 && hd.Abs() < hdate.ToRD(year, hdate.Tishrei, simchatTorah) {
+				return "Vezot Haberakhah"
+			}
+			return parsha.Name[0]
+		}
+		abs += 7
+	}
+}
+
+// WeekdayReading returns the Torah reading on hd if it is a Monday,
+// Thursday or Shabbat afternoon (Mincha). It is the first aliyah of the
+// upcoming parsha, in the traditional division into three aliyot.
+// Monday and Thursday read from the parsha of the coming Shabbat, and
+// Shabbat Mincha from the parsha of the following week. When parshiyot
+// are doubled, the reading is from the first.
+//
+// An error is returned if hd is any other day of the week.
+// WeekdayReading doesn't consider holidays; when LookupHoliday finds a
+// reading for a weekday (or a Mincha reading for Shabbat), that
+// reading replaces this one.
+func WeekdayReading(hd hdate.HDate, il bool) (Reading, error) {
+	abs := hd.Abs()
+	var saturday hdate.HDate
+	switch hd.Weekday() {
+	case time.Monday, time.Thursday:
+		saturday = hdate.FromRD(hdate.DayOnOrBefore(time.Saturday, abs+6))
+	case time.Saturday:
+		saturday = hdate.FromRD(abs + 7)
+	default:
+		return Reading{}, errors.New("no weekday Torah reading on " + hd.Weekday().String())
+	}
+	name := upcomingParsha(hd, saturday, il)
+	data, ok := weekdayData[name]
+	if !ok {
+		return Reading{}, errors.New("unknown parsha " + name)
+	}
+	book := parshaData[name].book
+	r := Reading{Parsha: []string{name}, Aliyot: make([]Aliyah, 3)}
+	for i, verses := range data {
+		r.Aliyot[i] = newAliyah(book, verses)
+	}
+	begin := r.Aliyot[0].Begin
+	end := r.Aliyot[2].End
+	r.Torah = Aliyah{Book: book, Begin: begin, End: end, Verses: countVerses(book, begin, end)}
+	return r, nil
+}
@@ -0,0 +1,83 @@
+package leyning_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/leyning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekdayReading(t *testing.T) {
+	assert := assert.New(t)
+	// Monday before Lech-Lecha
+	r, err := leyning.WeekdayReading(hdate.FromGregorian(2022, time.October, 31), false)
+	assert.Nil(err)
+	assert.Equal([]string{"Lech-Lecha"}, r.Parsha)
+	assert.Equal("Genesis 12:1-13", r.Torah.String())
+	assert.Equal(3, len(r.Aliyot))
+	assert.Equal("Genesis 12:1-3", r.Aliyot[0].String())
+	assert.Equal("Genesis 12:4-9", r.Aliyot[1].String())
+	assert.Equal("Genesis 12:10-13", r.Aliyot[2].String())
+
+	// Shabbat Mincha reads from the following week's parsha
+	r, _ = leyning.WeekdayReading(hdate.FromGregorian(2022, time.October, 29), false)
+	assert.Equal([]string{"Lech-Lecha"}, r.Parsha)
+
+	// Doubled parshiyot read from the first; Tazria's third
+	// aliyah continues past the end of chapter 12
+	r, _ = leyning.WeekdayReading(hdate.FromGregorian(2023, time.April, 17), false)
+	assert.Equal([]string{"Tazria"}, r.Parsha)
+	assert.Equal("Leviticus 13:1-5", r.Aliyot[2].String())
+
+	// The third aliyah may extend into the second Shabbat aliyah
+	r, _ = leyning.WeekdayReading(hdate.FromGregorian(2023, time.July, 6), false)
+	assert.Equal([]string{"Pinchas"}, r.Parsha)
+	assert.Equal([]string{"Numbers 25:10-12", "Numbers 25:13-15", "Numbers 25:16-26:4"},
+		[]string{r.Aliyot[0].String(), r.Aliyot[1].String(), r.Aliyot[2].String()})
+
+	// Before Simchat Torah, the next parsha is Vezot Haberakhah
+	r, _ = leyning.WeekdayReading(hdate.FromGregorian(2022, time.October, 15), false)
+	assert.Equal([]string{"Vezot Haberakhah"}, r.Parsha)
+	assert.Equal("Deuteronomy 33:1-17", r.Torah.String())
+	assert.Equal(17, r.Torah.Verses)
+	r, _ = leyning.WeekdayReading(hdate.FromGregorian(2022, time.October, 20), false)
+	assert.Equal([]string{"Bereshit"}, r.Parsha)
+
+	// A holiday Shabbat skips ahead to the next parsha
+	r, _ = leyning.WeekdayReading(hdate.FromGregorian(2023, time.April, 3), false)
+	assert.Equal([]string{"Shmini"}, r.Parsha)
+
+	_, err = leyning.WeekdayReading(hdate.FromGregorian(2022, time.November, 1), false)
+	assert.NotNil(err)
+}
+
+func TestWeekdayReadingEveryParsha(t *testing.T) {
+	seen := map[string]bool{}
+	for _, il := range []bool{false, true} {
+		start := hdate.FromGregorian(2020, time.January, 2)
+		for hd := start; hd.Abs() < start.Abs()+7*52*4; hd = hdate.FromRD(hd.Abs() + 7) {
+			r, err := leyning.WeekdayReading(hd, il)
+			assert.Nil(t, err, hd.String())
+			assert.Equal(t, 3, len(r.Aliyot))
+			assert.Equal(t, r.Aliyot[0].Verses+r.Aliyot[1].Verses+r.Aliyot[2].Verses, r.Torah.Verses)
+			seen[r.Parsha[0]] = true
+		}
+	}
+	assert.Equal(t, 54, len(seen))
+}
+
+func ExampleWeekdayReading() {
+	r, _ := leyning.WeekdayReading(hdate.FromGregorian(2022, time.October, 20), false)
+	fmt.Println(r.Parsha[0])
+	for i, aliyah := range r.Aliyot {
+		fmt.Println(i+1, aliyah)
+	}
+	// Output:
+	// Bereshit
+	// 1 Genesis 1:1-5
+	// 2 Genesis 1:6-8
+	// 3 Genesis 1:9-13
+}
@@ -77,6 +77,7 @@ var dict_ashkenazi = map[string]string{
 	"Maasrot": "Maasros",
 	"Eduyot": "Eduyos",
 	"Avot": "Avos",
+	"Pirkei Avot": "Pirkei Avos",
 	"Bekhorot": "Bekhoros",
 	"Middot": "Middos",
 	"Oholot": "Oholos",
@@ -252,6 +252,11 @@ var dict_he = map[string]string{
 	"after sunset": "אחרי השקיעה",
 	"Yerushalmi": "יְרוּשַׁלְמִי",
 	"Chag HaBanot": "חַג הַבָּנוֹת",
+	"Genesis": "בְּרֵאשִׁית",
+	"Exodus": "שְׁמוֹת",
+	"Leviticus": "וַיִּקְרָא",
+	"Numbers": "בְּמִדְבַּר",
+	"Deuteronomy": "דְּבָרִים",
 	"Joshua": "יְהוֹשׁוּעַ",
 	"Judges": "שׁוֹפְטִים",
 	"I Samuel": "שְׁמוּאֵל רִאשׁוֹן",
@@ -293,6 +298,28 @@ var dict_he = map[string]string{
 	"Ta'anit Esther (Mincha)": "תַּעֲנִית אֶסְתֵּר מִנחָה",
 	"Tzom Gedaliah (Mincha)": "צוֹם גְּדַלְיָה מִנחָה",
 	"Tzom Tammuz (Mincha)": "צוֹם תָּמוּז מִנחָה",
+	"Torah reading": "קְרִיאַת הַתּוֹרָה",
+	"Shabbat Mincha": "מִנְחָה שֶׁל שַׁבָּת",
+	"Pirkei Avot": "פִּרְקֵי אָבוֹת",
+	"Tehillim": "תְּהִלִּים",
+	"Weekly Tehillim": "תְּהִלִּים לְיוֹם הַשָּׁבוּעַ",
+	"Daf HaShavua": "דַּף הַשָּׁבוּעַ",
+	"Israel": "אֶרֶץ יִשְׂרָאֵל",
+	"Diaspora": "חוּץ לָאָרֶץ",
+	"Introduction": "הקדמה",
+	"Knowledge": "המדע",
+	"Love": "אהבה",
+	"Seasons": "זמנים",
+	"Women": "נשים",
+	"Holiness": "קדושה",
+	"Utterances": "הפלאה",
+	"Seeds": "זרעים",
+	"Temple Service": "עבודה",
+	"Sacrifices": "קרבנות",
+	"Purity": "טהרה",
+	"Damages": "נזיקין",
+	"Acquisition": "קנין",
+	"Judgments": "משפטים",
 	"Berachot": "ברכות",
 	"Eruvin": "עירובין",
 	"Pesachim": "פסחים",
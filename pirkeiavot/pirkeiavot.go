@@ -0,0 +1,94 @@
+// Hebcal's pirkeiavot package calculates the schedule for reading
+// Pirkei Avot (Ethics of the Fathers), one chapter each Shabbat
+// afternoon from Pesach until Rosh Hashana.
+package pirkeiavot
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/event"
+)
+
+const numChapters = 6
+
+// Schedule maps the R.D. date of each Shabbat between Pesach and
+// Rosh Hashana to the chapter (or chapters) of Pirkei Avot read that
+// afternoon.
+type Schedule map[int64][]int
+
+// New computes the schedule for the summer of the Hebrew year.
+//
+// holidays are the holidays of that year for either the Israel or
+// Diaspora schedule, as returned by hebcal.GetHolidaysForYear(). They
+// determine when Pesach ends and which Shabbatot are skipped: those
+// that are Yom Tov, or Tish'a B'Av postponed to Sunday.
+//
+// The six chapters are read in order starting the Shabbat after Pesach
+// and repeat as often as the summer allows. When fewer than six
+// Shabbatot remain for the last round, chapters are combined at the end
+// so that it finishes on the Shabbat before Rosh Hashana.
+func New(year int, holidays []event.HolidayEvent) Schedule {
+	pesachEnd := hdate.ToRD(year, hdate.Nisan, 15)
+	skip := make(map[int64]bool)
+	for _, ev := range holidays {
+		abs := ev.Date.Abs()
+		if (ev.Flags & event.CHAG) != 0 {
+			skip[abs] = true
+			if ev.Date.Month() == hdate.Nisan && abs > pesachEnd {
+				pesachEnd = abs
+			}
+		}
+		if ev.Desc == "Tish'a B'Av (observed)" {
+			skip[abs-1] = true
+		}
+	}
+	rh := hdate.ToRD(year+1, hdate.Tishrei, 1)
+	saturdays := make([]int64, 0, 26)
+	for abs := hdate.DayOnOrBefore(time.Saturday, pesachEnd+7); abs < rh; abs += 7 {
+		if !skip[abs] {
+			saturdays = append(saturdays, abs)
+		}
+	}
+	sched := make(Schedule, len(saturdays))
+	n := len(saturdays)
+	remainder := n % numChapters
+	full := n - remainder
+	for i := 0; i < full; i++ {
+		sched[saturdays[i]] = []int{i%numChapters + 1}
+	}
+	chapter := 1
+	for i := 0; i < remainder; i++ {
+		// extra chapters go to the last Shabbatot
+		count := (numChapters + i) / remainder
+		chapters := make([]int, count)
+		for j := range chapters {
+			chapters[j] = chapter
+			chapter++
+		}
+		sched[saturdays[full+i]] = chapters
+	}
+	return sched
+}
+
+// Lookup returns the chapters of Pirkei Avot read on hd,
+// or nil if none are read that day.
+func (sched Schedule) Lookup(hd hdate.HDate) []int {
+	return sched[hd.Abs()]
+}
@@ -0,0 +1,53 @@
+package pirkeiavot_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/hebcal"
+	"github.com/MaxBGreenberg/hebcal-go/pirkeiavot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPirkeiAvot(t *testing.T) {
+	assert := assert.New(t)
+	sched := pirkeiavot.New(5783, hebcal.GetHolidaysForYear(5783, false))
+	assert.Equal(21, len(sched))
+	assert.Equal([]int{1}, sched.Lookup(hdate.FromGregorian(2023, time.April, 15)))
+	assert.Equal([]int{6}, sched.Lookup(hdate.FromGregorian(2023, time.May, 20)))
+	// Shavuot II on Shabbat
+	assert.Nil(sched.Lookup(hdate.FromGregorian(2023, time.May, 27)))
+	assert.Equal([]int{1}, sched.Lookup(hdate.FromGregorian(2023, time.June, 3)))
+	assert.Equal([]int{1, 2}, sched.Lookup(hdate.FromGregorian(2023, time.August, 26)))
+	assert.Equal([]int{5, 6}, sched.Lookup(hdate.FromGregorian(2023, time.September, 9)))
+	// not a Shabbat
+	assert.Nil(sched.Lookup(hdate.FromGregorian(2023, time.June, 4)))
+
+	// In Israel, 7 Sivan is an ordinary Shabbat
+	sched = pirkeiavot.New(5783, hebcal.GetHolidaysForYear(5783, true))
+	assert.Equal(22, len(sched))
+	assert.Equal([]int{1}, sched.Lookup(hdate.FromGregorian(2023, time.May, 27)))
+	assert.Equal([]int{3, 4}, sched.Lookup(hdate.FromGregorian(2023, time.September, 2)))
+}
+
+func TestPirkeiAvotTishaBav(t *testing.T) {
+	// Tish'a B'Av on Shabbat, observed on Sunday
+	sched := pirkeiavot.New(5782, hebcal.GetHolidaysForYear(5782, false))
+	assert.Nil(t, sched.Lookup(hdate.FromGregorian(2022, time.August, 6)))
+	assert.Equal(t, []int{3}, sched.Lookup(hdate.FromGregorian(2022, time.August, 13)))
+}
+
+func ExampleSchedule_Lookup() {
+	sched := pirkeiavot.New(5784, hebcal.GetHolidaysForYear(5784, false))
+	for _, day := range []int{7, 14, 21, 28} {
+		hd := hdate.FromGregorian(2024, time.September, day)
+		fmt.Println(hd, sched.Lookup(hd))
+	}
+	// Output:
+	// 4 Elul 5784 [1]
+	// 11 Elul 5784 [2]
+	// 18 Elul 5784 [3 4]
+	// 25 Elul 5784 [5 6]
+}
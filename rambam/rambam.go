@@ -0,0 +1,257 @@
+// Hebcal's rambam package calculates the Rambam Yomi, a daily
+// regimen of learning the Mishneh Torah of Maimonides in either
+// three chapters or one chapter a day.
+package rambam
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/greg"
+	"github.com/hebcal/hdate"
+)
+
+// Chapter represents a single chapter of Mishneh Torah,
+// such as Repentance 3
+type Chapter struct {
+	Book     string // Book (Sefer) name (e.g. Knowledge)
+	Halachot string // Section name (e.g. Repentance)
+	Chap     int    // Chapter number
+}
+
+// Reading is the chapter (or chapters) learned on the same day.
+type Reading []Chapter
+
+// Cycle is the pace of the Rambam Yomi.
+type Cycle int
+
+const (
+	// Three chapters a day, completing Mishneh Torah in 339 days
+	ThreeChapters Cycle = iota
+	// One chapter a day, completing Mishneh Torah in 1017 days
+	OneChapter
+)
+
+// A section (Hilchot) of Mishneh Torah and its number of chapters
+type section struct {
+	k string
+	v int
+}
+
+// The introduction, followed by the 14 books of Mishneh Torah.
+// The introduction is the Rambam's preface, his list of the positive
+// and negative mitzvot, and a table of contents for each of the 14
+// books, learned as 17 units before the 1000 chapters.
+var books = []struct {
+	book     string
+	halachot []section
+}{
+	{"Introduction", []section{
+		{"Transmission of the Oral Law", 1},
+		{"Positive Mitzvot", 1},
+		{"Negative Mitzvot", 1},
+		{"Overview of Mishneh Torah Contents", 14},
+	}},
+	{"Knowledge", []section{
+		{"Foundations of the Torah", 10},
+		{"Human Dispositions", 7},
+		{"Torah Study", 7},
+		{"Foreign Worship and Customs of the Nations", 12},
+		{"Repentance", 10},
+	}},
+	{"Love", []section{
+		{"Reading the Shema", 4},
+		{"Prayer and the Priestly Blessing", 15},
+		{"Tefillin, Mezuzah and the Torah Scroll", 10},
+		{"Fringes", 3},
+		{"Blessings", 11},
+		{"Circumcision", 3},
+	}},
+	{"Seasons", []section{
+		{"Sabbath", 30},
+		{"Eruvin", 8},
+		{"Rest on the Tenth of Tishrei", 3},
+		{"Rest on a Holiday", 8},
+		{"Leavened and Unleavened Bread", 8},
+		{"Shofar, Sukkah and Lulav", 8},
+		{"Sheqel Dues", 4},
+		{"Sanctification of the New Month", 19},
+		{"Fasts", 5},
+		{"Scroll of Esther and Hanukkah", 4},
+	}},
+	{"Women", []section{
+		{"Marriage", 25},
+		{"Divorce", 13},
+		{"Levirate Marriage and Release", 8},
+		{"Virgin Maiden", 3},
+		{"Woman Suspected of Infidelity", 4},
+	}},
+	{"Holiness", []section{
+		{"Forbidden Intercourse", 22},
+		{"Forbidden Foods", 17},
+		{"Ritual Slaughter", 14},
+	}},
+	{"Utterances", []section{
+		{"Oaths", 12},
+		{"Vows", 13},
+		{"Nazariteship", 10},
+		{"Appraisals and Devoted Property", 8},
+	}},
+	{"Seeds", []section{
+		{"Diverse Species", 10},
+		{"Gifts to the Poor", 10},
+		{"Heave Offerings", 15},
+		{"Tithes", 14},
+		{"Second Tithes and Fourth Year's Fruit", 11},
+		{"First Fruits and other Gifts to Priests Outside the Sanctuary", 12},
+		{"Sabbatical Year and the Jubilee", 13},
+	}},
+	{"Temple Service", []section{
+		{"The Chosen Temple", 8},
+		{"Vessels of the Sanctuary and Those who Serve Therein", 10},
+		{"Admission into the Sanctuary", 9},
+		{"Things Forbidden on the Altar", 7},
+		{"Sacrificial Procedure", 19},
+		{"Daily Offerings and Additional Offerings", 10},
+		{"Sacrifices Rendered Unfit", 19},
+		{"Service on the Day of Atonement", 5},
+		{"Trespass", 8},
+	}},
+	{"Sacrifices", []section{
+		{"Paschal Offering", 10},
+		{"Festival Offering", 3},
+		{"Firstlings", 8},
+		{"Offerings for Unintentional Transgressions", 15},
+		{"Offerings for Those with Incomplete Atonement", 5},
+		{"Substitution", 4},
+	}},
+	{"Purity", []section{
+		{"Defilement by a Corpse", 25},
+		{"Red Heifer", 15},
+		{"Defilement by Leprosy", 16},
+		{"Those Who Defile Bed or Seat", 13},
+		{"Other Sources of Defilement", 20},
+		{"Defilement of Foods", 16},
+		{"Vessels", 28},
+		{"Immersion Pools", 11},
+	}},
+	{"Damages", []section{
+		{"Damages to Property", 14},
+		{"Theft", 9},
+		{"Robbery and Lost Property", 18},
+		{"One Who Injures a Person or Property", 8},
+		{"Murderer and the Preservation of Life", 13},
+	}},
+	{"Acquisition", []section{
+		{"Sales", 30},
+		{"Ownerless Property and Gifts", 12},
+		{"Neighbors", 14},
+		{"Agents and Partners", 10},
+		{"Slaves", 9},
+	}},
+	{"Judgments", []section{
+		{"Hiring", 13},
+		{"Borrowing and Deposit", 8},
+		{"Creditor and Debtor", 27},
+		{"Plaintiff and Defendant", 16},
+		{"Inheritances", 11},
+	}},
+	{"Judges", []section{
+		{"The Sanhedrin and the Penalties within their Jurisdiction", 26},
+		{"Testimony", 22},
+		{"Rebels", 7},
+		{"Mourning", 14},
+		{"Kings and Wars", 12},
+	}},
+}
+
+// RambamStart is the R.D. number of the start of both Rambam Yomi
+// cycles, corresponding to 29 April 1984 (27 Nisan 5744).
+var RambamStart = greg.ToRD(1984, time.April, 29)
+
+const numChapters = 1017
+
+// RambamYomiIndex is an index by chapter number of the entire
+// Mishneh Torah, including the introduction.
+type RambamYomiIndex []Chapter
+
+// MakeIndex initializes the index for Rambam Yomi.
+func MakeIndex() RambamYomiIndex {
+	chapters := make(RambamYomiIndex, 0, numChapters)
+	for _, book := range books {
+		for _, section := range book.halachot {
+			for chap := 1; chap <= section.v; chap++ {
+				chapters = append(chapters, Chapter{Book: book.book, Halachot: section.k, Chap: chap})
+			}
+		}
+	}
+	return chapters
+}
+
+// RambamYomiIndex.Lookup calculates the Rambam Yomi for given date
+// in the three chapter or one chapter cycle.
+//
+// Returns an error if the date is before the Rambam Yomi cycles began
+// (29 April 1984).
+func (idx RambamYomiIndex) Lookup(hd hdate.HDate, cycle Cycle) (Reading, error) {
+	abs := hd.Abs()
+	if abs < RambamStart {
+		return Reading{}, errors.New("before Rambam Yomi cycle began")
+	}
+	perDay := int64(3)
+	if cycle == OneChapter {
+		perDay = 1
+	}
+	numDays := numChapters / perDay
+	first := ((abs - RambamStart) % numDays) * perDay
+	return Reading(idx[first : first+perDay]), nil
+}
+
+// Returns a string representation of the Rambam Yomi,
+// such as "Repentance 10, Reading the Shema 1-2".
+func (r Reading) String() string {
+	return r.Format(func(s string) string { return s }, strconv.Itoa, "-")
+}
+
+// Format returns a string representation of the Rambam Yomi using
+// name to translate section names, num to format chapter numbers,
+// and sep between the first and last chapter of a section.
+func (r Reading) Format(name func(string) string, num func(int) string, sep string) string {
+	parts := make([]string, 0, 2)
+	for i := 0; i < len(r); {
+		j := i
+		for j+1 < len(r) && r[j+1].Halachot == r[i].Halachot {
+			j++
+		}
+		s := name(r[i].Halachot) + " " + num(r[i].Chap)
+		if j != i {
+			s += sep + num(r[j].Chap)
+		}
+		parts = append(parts, s)
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Returns a string representation of the chapter.
+func (c Chapter) String() string {
+	return c.Halachot + " " + strconv.Itoa(c.Chap)
+}
@@ -0,0 +1,73 @@
+package rambam_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/rambam"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeIndex(t *testing.T) {
+	idx := rambam.MakeIndex()
+	assert.Equal(t, 1017, len(idx))
+	assert.Equal(t, rambam.Chapter{Book: "Introduction", Halachot: "Transmission of the Oral Law", Chap: 1}, idx[0])
+	assert.Equal(t, rambam.Chapter{Book: "Introduction", Halachot: "Overview of Mishneh Torah Contents", Chap: 14}, idx[16])
+	assert.Equal(t, rambam.Chapter{Book: "Knowledge", Halachot: "Foundations of the Torah", Chap: 1}, idx[17])
+	assert.Equal(t, rambam.Chapter{Book: "Judges", Halachot: "Kings and Wars", Chap: 12}, idx[1016])
+}
+
+func TestThreeChapters(t *testing.T) {
+	idx := rambam.MakeIndex()
+	// the first Siyum HaRambam was on 11 Nisan 5745
+	reading, err := idx.Lookup(hdate.FromGregorian(1984, time.April, 29), rambam.ThreeChapters)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Transmission of the Oral Law 1, Positive Mitzvot 1, Negative Mitzvot 1", reading.String())
+	reading, _ = idx.Lookup(hdate.New(5745, hdate.Nisan, 11), rambam.ThreeChapters)
+	assert.Equal(t, "Kings and Wars 10-12", reading.String())
+	// the 42nd Siyum HaRambam was on 1 Iyyar 5783, and the 43rd
+	// cycle began the next day, 23 April 2023
+	reading, _ = idx.Lookup(hdate.New(5783, hdate.Iyyar, 1), rambam.ThreeChapters)
+	assert.Equal(t, "Kings and Wars 10-12", reading.String())
+	reading, _ = idx.Lookup(hdate.FromGregorian(2023, time.April, 23), rambam.ThreeChapters)
+	assert.Equal(t, "Transmission of the Oral Law 1, Positive Mitzvot 1, Negative Mitzvot 1", reading.String())
+	reading, _ = idx.Lookup(hdate.FromGregorian(2023, time.April, 24), rambam.ThreeChapters)
+	assert.Equal(t, "Overview of Mishneh Torah Contents 1-3", reading.String())
+	reading, _ = idx.Lookup(hdate.FromGregorian(2023, time.April, 28), rambam.ThreeChapters)
+	assert.Equal(t, "Overview of Mishneh Torah Contents 13-14, Foundations of the Torah 1", reading.String())
+	reading, _ = idx.Lookup(hdate.FromGregorian(2024, time.March, 26), rambam.ThreeChapters)
+	assert.Equal(t, "Kings and Wars 10-12", reading.String())
+	reading, _ = idx.Lookup(hdate.FromGregorian(2024, time.March, 27), rambam.ThreeChapters)
+	assert.Equal(t, "Transmission of the Oral Law 1, Positive Mitzvot 1, Negative Mitzvot 1", reading.String())
+}
+
+func TestOneChapter(t *testing.T) {
+	idx := rambam.MakeIndex()
+	reading, err := idx.Lookup(hdate.FromGregorian(2023, time.April, 23), rambam.OneChapter)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, rambam.Reading{{Book: "Introduction", Halachot: "Transmission of the Oral Law", Chap: 1}}, reading)
+	reading, _ = idx.Lookup(hdate.FromGregorian(2023, time.May, 10), rambam.OneChapter)
+	assert.Equal(t, "Foundations of the Torah 1", reading.String())
+	reading, _ = idx.Lookup(hdate.FromGregorian(2026, time.February, 2), rambam.OneChapter)
+	assert.Equal(t, "Kings and Wars 12", reading.String())
+	// the one chapter cycle finishes with every third three chapter cycle
+	reading, _ = idx.Lookup(hdate.New(5783, hdate.Iyyar, 1), rambam.OneChapter)
+	assert.Equal(t, "Kings and Wars 12", reading.String())
+}
+
+func TestRambamBefore(t *testing.T) {
+	idx := rambam.MakeIndex()
+	reading, err := idx.Lookup(hdate.FromGregorian(1984, time.April, 28), rambam.ThreeChapters)
+	assert.Equal(t, errors.New("before Rambam Yomi cycle began"), err)
+	assert.Equal(t, 0, len(reading))
+}
+
+func ExampleRambamYomiIndex_Lookup() {
+	idx := rambam.MakeIndex()
+	reading, _ := idx.Lookup(hdate.FromGregorian(2022, time.August, 1), rambam.ThreeChapters)
+	fmt.Println(reading)
+	// Output: Marriage 17-19
+}
@@ -0,0 +1,407 @@
+package sedra
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hebcal/hdate"
+)
+
+// TriennialStartYear is the Hebrew year whose Bereshit began a
+// triennial cycle (5744, in October 1983). A new cycle begins
+// every three years thereafter.
+const TriennialStartYear = 5744
+
+// TriennialReading is the Torah reading for one Shabbat in the
+// three-year (triennial) cycle, in which a third of each parsha
+// is read each year.
+type TriennialReading struct {
+	Parsha Parsha
+	// Year of the triennial cycle: 1, 2 or 3
+	Year int
+	// The seven aliyot, e.g. "Genesis 1:1-5"
+	Aliyot []string
+	// The maftir, e.g. "Genesis 2:1-3"
+	Maftir string
+}
+
+// TriennialYear returns the year of the triennial cycle (1, 2 or 3)
+// that begins with Bereshit in the given Hebrew year.
+func TriennialYear(year int) int {
+	n := (year - TriennialStartYear) % 3
+	if n < 0 {
+		n += 3
+	}
+	return n + 1
+}
+
+// TriennialAliyot returns the seven aliyot and the maftir read in the
+// given year (1, 2 or 3) of the triennial cycle for the parsha named name.
+//
+// Doubled parshiyot such as "Vayakhel-Pekudei" read the same year's
+// portion of both parshiyot: the first three aliyot cover the first
+// parsha (its aliyot 1-2, 3-4 and 5-7), the last four cover the second
+// (1-2, 3-4, 5-6 and 7), and the maftir is that of the second parsha.
+// Behar, Bechukotai, Nitzavim, Vayeilech and Ha'azinu are too short to
+// divide and are read in full every year, as are Behar-Bechukotai and
+// Nitzavim-Vayeilech.
+func TriennialAliyot(name string, year int) (aliyot []string, maftir string, err error) {
+	if year < 1 || year > 3 {
+		return nil, "", errors.New("invalid triennial year " + strconv.Itoa(year))
+	}
+	if data, ok := triennialData[name]; ok {
+		verses := data.years[year-1]
+		aliyot = make([]string, 7)
+		for i := range aliyot {
+			aliyot[i] = data.book + " " + verses[i]
+		}
+		return aliyot, data.book + " " + verses[7], nil
+	}
+	parts := strings.Split(name, "-")
+	if len(parts) != 2 {
+		return nil, "", errors.New("unknown parsha " + name)
+	}
+	first, ok1 := triennialData[parts[0]]
+	second, ok2 := triennialData[parts[1]]
+	if !ok1 || !ok2 {
+		return nil, "", errors.New("unknown parsha " + name)
+	}
+	a, b := first.years[year-1], second.years[year-1]
+	aliyot = []string{
+		first.book + " " + joinVerses(a[0], a[1]),
+		first.book + " " + joinVerses(a[2], a[3]),
+		first.book + " " + joinVerses(a[4], a[6]),
+		second.book + " " + joinVerses(b[0], b[1]),
+		second.book + " " + joinVerses(b[2], b[3]),
+		second.book + " " + joinVerses(b[4], b[5]),
+		second.book + " " + b[6],
+	}
+	return aliyot, second.book + " " + b[7], nil
+}
+
+// joinVerses returns the range from the start of from to the end of to,
+// e.g. joinVerses("35:1-3", "35:4-10") => "35:1-10" and
+// joinVerses("35:30-35", "36:1-7") => "35:30-36:7".
+func joinVerses(from, to string) string {
+	start := strings.Split(from, "-")[0]
+	toParts := strings.Split(to, "-")
+	end := toParts[len(toParts)-1]
+	if !strings.Contains(end, ":") {
+		end = strings.Split(toParts[0], ":")[0] + ":" + end
+	}
+	startChap := strings.Split(start, ":")[0]
+	endParts := strings.Split(end, ":")
+	if startChap == endParts[0] {
+		return start + "-" + endParts[1]
+	}
+	return start + "-" + end
+}
+
+// LookupTriennial returns the triennial reading for the Saturday on
+// or after hd, or an error if a holiday reading replaces the parsha.
+//
+// The cycle year advances with Bereshit, so Vayeilech and Ha'azinu read
+// in Tishrei belong to the cycle year of the preceding Hebrew year.
+func (sedra *Sedra) LookupTriennial(hd hdate.HDate) (TriennialReading, error) {
+	parsha := sedra.Lookup(hd)
+	if parsha.Chag {
+		return TriennialReading{}, errors.New("holiday reading on " + hd.String())
+	}
+	saturday := hdate.FromRD(hdate.DayOnOrBefore(time.Saturday, hd.Abs()+6))
+	year := saturday.Year()
+	if saturday.Month() == hdate.Tishrei && parsha.Num[0] >= 52 {
+		year--
+	}
+	r := TriennialReading{Parsha: parsha, Year: TriennialYear(year)}
+	var err error
+	r.Aliyot, r.Maftir, err = TriennialAliyot(strings.Join(parsha.Name, "-"), r.Year)
+	return r, err
+}
+
+type triennialParsha struct {
+	book string
+	// seven aliyot and the maftir for each year of the cycle
+	years [3][8]string
+}
+
+// Parshiyot read in full every year, with the aliyot of the annual cycle
+var (
+	beharAnnual             = [8]string{"25:1-13", "25:14-18", "25:19-24", "25:25-28", "25:29-38", "25:39-46", "25:47-26:2", "25:55-26:2"}
+	bechukotaiAnnual        = [8]string{"26:3-5", "26:6-9", "26:10-46", "27:1-15", "27:16-21", "27:22-28", "27:29-34", "27:32-34"}
+	beharBechukotaiAnnual   = [8]string{"25:1-18", "25:19-24", "25:25-38", "25:39-46", "25:47-26:9", "26:10-46", "27:1-34", "27:32-34"}
+	nitzavimAnnual          = [8]string{"29:9-11", "29:12-14", "29:15-28", "30:1-6", "30:7-10", "30:11-14", "30:15-20", "30:15-20"}
+	vayeilechAnnual         = [8]string{"31:1-3", "31:4-6", "31:7-9", "31:10-13", "31:14-19", "31:20-24", "31:25-30", "31:28-30"}
+	nitzavimVayeilechAnnual = [8]string{"29:9-28", "30:1-6", "30:7-14", "30:15-31:6", "31:7-13", "31:14-19", "31:20-30", "31:28-30"}
+	haazinuAnnual           = [8]string{"32:1-6", "32:7-12", "32:13-18", "32:19-28", "32:29-39", "32:40-43", "32:44-52", "32:48-52"}
+)
+
+// triennialData holds the chapter and verse ranges of the seven aliyot
+// and the maftir read in each year of the triennial cycle, following the
+// division of each parsha into thirds used by hebcal.com.
+var triennialData = map[string]triennialParsha{
+	"Bereshit": {"Genesis", [3][8]string{
+		{"1:1-5", "1:6-8", "1:9-13", "1:14-19", "1:20-23", "1:24-31", "2:1-3", "2:1-3"},
+		{"2:4-9", "2:10-19", "2:20-25", "3:1-21", "3:22-24", "4:1-18", "4:19-26", "4:23-26"},
+		{"5:1-5", "5:6-8", "5:9-14", "5:15-20", "5:21-24", "5:25-31", "5:32-6:8", "6:5-8"},
+	}},
+	"Noach": {"Genesis", [3][8]string{
+		{"6:9-16", "6:17-19", "6:20-22", "7:1-9", "7:10-16", "7:17-24", "8:1-14", "8:12-14"},
+		{"8:15-22", "9:1-7", "9:8-17", "9:18-29", "10:1-14", "10:15-20", "10:21-32", "10:26-32"},
+		{"11:1-4", "11:5-9", "11:10-13", "11:14-17", "11:18-21", "11:22-25", "11:26-32", "11:29-32"},
+	}},
+	"Lech-Lecha": {"Genesis", [3][8]string{
+		{"12:1-3", "12:4-9", "12:10-13", "12:14-20", "13:1-4", "13:5-11", "13:12-18", "13:16-18"},
+		{"14:1-9", "14:10-16", "14:17-20", "14:21-24", "15:1-6", "15:7-11", "15:12-21", "15:18-21"},
+		{"16:1-6", "16:7-9", "16:10-16", "17:1-6", "17:7-17", "17:18-23", "17:24-27", "17:24-27"},
+	}},
+	"Vayera": {"Genesis", [3][8]string{
+		{"18:1-5", "18:6-8", "18:9-14", "18:15-19", "18:20-22", "18:23-26", "18:27-33", "18:31-33"},
+		{"19:1-8", "19:9-14", "19:15-20", "19:21-29", "19:30-38", "20:1-7", "20:8-18", "20:15-18"},
+		{"21:1-4", "21:5-13", "21:14-21", "21:22-34", "22:1-8", "22:9-14", "22:15-24", "22:20-24"},
+	}},
+	"Chayei Sara": {"Genesis", [3][8]string{
+		{"23:1-4", "23:5-7", "23:8-12", "23:13-16", "23:17-20", "24:1-4", "24:5-9", "24:7-9"},
+		{"24:10-14", "24:15-20", "24:21-26", "24:27-33", "24:34-39", "24:40-45", "24:46-52", "24:50-52"},
+		{"24:53-55", "24:56-58", "24:59-61", "24:62-67", "25:1-6", "25:7-11", "25:12-18", "25:16-18"},
+	}},
+	"Toldot": {"Genesis", [3][8]string{
+		{"25:19-22", "25:23-26", "25:27-34", "26:1-5", "26:6-12", "26:13-16", "26:17-22", "26:20-22"},
+		{"26:23-29", "26:30-35", "27:1-4", "27:5-13", "27:14-17", "27:18-21", "27:22-27", "27:25-27"},
+		{"27:28-30", "27:31-33", "27:34-37", "27:38-40", "27:41-46", "28:1-4", "28:5-9", "28:7-9"},
+	}},
+	"Vayetzei": {"Genesis", [3][8]string{
+		{"28:10-12", "28:13-17", "28:18-22", "29:1-8", "29:9-17", "29:18-30", "29:31-30:13", "30:9-13"},
+		{"30:14-16", "30:17-21", "30:22-24", "30:25-27", "30:28-43", "31:1-9", "31:10-16", "31:14-16"},
+		{"31:17-21", "31:22-24", "31:25-32", "31:33-35", "31:36-42", "31:43-54", "32:1-3", "32:1-3"},
+	}},
+	"Vayishlach": {"Genesis", [3][8]string{
+		{"32:4-6", "32:7-9", "32:10-13", "32:14-22", "32:23-30", "32:31-33:5", "33:6-20", "33:18-20"},
+		{"34:1-4", "34:5-12", "34:13-17", "34:18-23", "34:24-31", "35:1-8", "35:9-15", "35:13-15"},
+		{"35:16-20", "35:21-26", "35:27-29", "36:1-8", "36:9-19", "36:20-30", "36:31-43", "36:40-43"},
+	}},
+	"Vayeshev": {"Genesis", [3][8]string{
+		{"37:1-3", "37:4-7", "37:8-11", "37:12-17", "37:18-22", "37:23-28", "37:29-36", "37:34-36"},
+		{"38:1-5", "38:6-11", "38:12-14", "38:15-18", "38:19-23", "38:24-26", "38:27-30", "38:28-30"},
+		{"39:1-6", "39:7-18", "39:19-23", "40:1-8", "40:9-15", "40:16-19", "40:20-23", "40:20-23"},
+	}},
+	"Miketz": {"Genesis", [3][8]string{
+		{"41:1-4", "41:5-7", "41:8-14", "41:15-24", "41:25-38", "41:39-44", "41:45-52", "41:50-52"},
+		{"41:53-57", "42:1-5", "42:6-17", "42:18-28", "42:29-35", "42:36-38", "43:1-15", "43:11-15"},
+		{"43:16-18", "43:19-22", "43:23-25", "43:26-30", "43:31-34", "44:1-10", "44:11-17", "44:14-17"},
+	}},
+	"Vayigash": {"Genesis", [3][8]string{
+		{"44:18-20", "44:21-24", "44:25-30", "44:31-34", "45:1-7", "45:8-18", "45:19-27", "45:25-27"},
+		{"45:28-46:2", "46:3-7", "46:8-11", "46:12-15", "46:16-18", "46:19-22", "46:23-27", "46:25-27"},
+		{"46:28-30", "46:31-34", "47:1-4", "47:5-10", "47:11-17", "47:18-22", "47:23-27", "47:25-27"},
+	}},
+	"Vayechi": {"Genesis", [3][8]string{
+		{"47:28-31", "48:1-3", "48:4-9", "48:10-12", "48:13-16", "48:17-19", "48:20-22", "48:20-22"},
+		{"49:1-4", "49:5-7", "49:8-12", "49:13-15", "49:16-18", "49:19-21", "49:22-26", "49:24-26"},
+		{"49:27-29", "49:30-33", "50:1-6", "50:7-14", "50:15-18", "50:19-21", "50:22-26", "50:23-26"},
+	}},
+	"Shemot": {"Exodus", [3][8]string{
+		{"1:1-7", "1:8-12", "1:13-17", "1:18-22", "2:1-10", "2:11-15", "2:16-25", "2:23-25"},
+		{"3:1-6", "3:7-10", "3:11-15", "3:16-22", "4:1-5", "4:6-9", "4:10-17", "4:14-17"},
+		{"4:18-23", "4:24-26", "4:27-31", "5:1-9", "5:10-14", "5:15-21", "5:22-6:1", "5:22-6:1"},
+	}},
+	"Vaera": {"Exodus", [3][8]string{
+		{"6:2-5", "6:6-9", "6:10-13", "6:14-19", "6:20-25", "6:26-28", "6:29-7:7", "7:5-7"},
+		{"7:8-13", "7:14-18", "7:19-25", "7:26-29", "8:1-6", "8:7-11", "8:12-15", "8:13-15"},
+		{"8:16-19", "8:20-28", "9:1-7", "9:8-12", "9:13-21", "9:22-26", "9:27-35", "9:33-35"},
+	}},
+	"Bo": {"Exodus", [3][8]string{
+		{"10:1-3", "10:4-6", "10:7-11", "10:12-15", "10:16-20", "10:21-23", "10:24-11:3", "11:1-3"},
+		{"11:4-10", "12:1-6", "12:7-10", "12:11-13", "12:14-16", "12:17-20", "12:21-28", "12:25-28"},
+		{"12:29-32", "12:33-36", "12:37-42", "12:43-51", "13:1-4", "13:5-10", "13:11-16", "13:14-16"},
+	}},
+	"Beshalach": {"Exodus", [3][8]string{
+		{"13:17-22", "14:1-8", "14:9-14", "14:15-25", "14:26-31", "15:1-21", "15:22-26", "15:22-26"},
+		{"15:27-16:3", "16:4-7", "16:8-10", "16:11-15", "16:16-18", "16:19-21", "16:22-27", "16:25-27"},
+		{"16:28-30", "16:31-33", "16:34-36", "17:1-4", "17:5-7", "17:8-13", "17:14-16", "17:14-16"},
+	}},
+	"Yitro": {"Exodus", [3][8]string{
+		{"18:1-3", "18:4-6", "18:7-9", "18:10-12", "18:13-15", "18:16-18", "18:19-21", "18:19-21"},
+		{"18:22-24", "18:25-27", "19:1-3", "19:4-6", "19:7-9", "19:10-12", "19:13-15", "19:13-15"},
+		{"19:16-19", "19:20-22", "19:23-25", "20:1-14", "20:15-17", "20:18-20", "20:21-23", "20:19-23"},
+	}},
+	"Mishpatim": {"Exodus", [3][8]string{
+		{"21:1-6", "21:7-11", "21:12-14", "21:15-19", "21:20-27", "21:28-32", "21:33-22:3", "21:37-22:3"},
+		{"22:4-6", "22:7-11", "22:12-16", "22:17-19", "22:20-23", "22:24-26", "22:27-23:5", "23:3-5"},
+		{"23:6-9", "23:10-13", "23:14-19", "23:20-25", "23:26-33", "24:1-11", "24:12-18", "24:15-18"},
+	}},
+	"Terumah": {"Exodus", [3][8]string{
+		{"25:1-5", "25:6-9", "25:10-16", "25:17-22", "25:23-30", "25:31-35", "25:36-40", "25:37-40"},
+		{"26:1-6", "26:7-11", "26:12-14", "26:15-17", "26:18-21", "26:22-25", "26:26-30", "26:28-30"},
+		{"26:31-33", "26:34-37", "27:1-3", "27:4-8", "27:9-11", "27:12-16", "27:17-19", "27:17-19"},
+	}},
+	"Tetzaveh": {"Exodus", [3][8]string{
+		{"27:20-28:2", "28:3-5", "28:6-8", "28:9-14", "28:15-21", "28:22-25", "28:26-30", "28:28-30"},
+		{"28:31-35", "28:36-39", "28:40-43", "29:1-4", "29:5-9", "29:10-14", "29:15-18", "29:16-18"},
+		{"29:19-21", "29:22-25", "29:26-30", "29:31-37", "29:38-42", "29:43-46", "30:1-10", "30:8-10"},
+	}},
+	"Ki Tisa": {"Exodus", [3][8]string{
+		{"30:11-16", "30:17-21", "30:22-33", "30:34-38", "31:1-5", "31:6-11", "31:12-17", "31:15-17"},
+		{"31:18-32:6", "32:7-10", "32:11-14", "32:15-20", "32:21-29", "32:30-35", "33:1-11", "33:7-11"},
+		{"33:12-16", "33:17-23", "34:1-3", "34:4-9", "34:10-17", "34:18-26", "34:27-35", "34:33-35"},
+	}},
+	"Vayakhel": {"Exodus", [3][8]string{
+		{"35:1-3", "35:4-10", "35:11-16", "35:17-20", "35:21-23", "35:24-26", "35:27-29", "35:27-29"},
+		{"35:30-35", "36:1-7", "36:8-13", "36:14-19", "36:20-38", "37:1-9", "37:10-16", "37:13-16"},
+		{"37:17-21", "37:22-24", "37:25-29", "38:1-3", "38:4-7", "38:8-11", "38:12-20", "38:18-20"},
+	}},
+	"Pekudei": {"Exodus", [3][8]string{
+		{"38:21-23", "38:24-27", "38:28-31", "39:1-4", "39:5-7", "39:8-14", "39:15-21", "39:19-21"},
+		{"39:22-24", "39:25-27", "39:28-30", "39:31-33", "39:34-36", "39:37-39", "39:40-43", "39:41-43"},
+		{"40:1-8", "40:9-16", "40:17-19", "40:20-23", "40:24-27", "40:28-33", "40:34-38", "40:34-38"},
+	}},
+	"Vayikra": {"Leviticus", [3][8]string{
+		{"1:1-4", "1:5-9", "1:10-13", "1:14-17", "2:1-6", "2:7-10", "2:11-16", "2:14-16"},
+		{"3:1-5", "3:6-11", "3:12-17", "4:1-7", "4:8-12", "4:13-21", "4:22-26", "4:24-26"},
+		{"4:27-31", "4:32-35", "5:1-6", "5:7-10", "5:11-13", "5:14-19", "5:20-26", "5:24-26"},
+	}},
+	"Tzav": {"Leviticus", [3][8]string{
+		{"6:1-3", "6:4-6", "6:7-11", "6:12-16", "6:17-23", "7:1-6", "7:7-10", "7:8-10"},
+		{"7:11-15", "7:16-18", "7:19-21", "7:22-27", "7:28-31", "7:32-34", "7:35-38", "7:35-38"},
+		{"8:1-5", "8:6-9", "8:10-13", "8:14-17", "8:18-21", "8:22-29", "8:30-36", "8:33-36"},
+	}},
+	"Shmini": {"Leviticus", [3][8]string{
+		{"9:1-3", "9:4-6", "9:7-9", "9:10-12", "9:13-15", "9:16-18", "9:19-23", "9:21-23"},
+		{"9:24-10:2", "10:3-5", "10:6-8", "10:9-11", "10:12-14", "10:15-17", "10:18-20", "10:18-20"},
+		{"11:1-8", "11:9-12", "11:13-23", "11:24-28", "11:29-32", "11:33-40", "11:41-47", "11:45-47"},
+	}},
+	"Tazria": {"Leviticus", [3][8]string{
+		{"12:1-3", "12:4-8", "13:1-3", "13:4-6", "13:7-9", "13:10-12", "13:13-15", "13:13-15"},
+		{"13:16-18", "13:19-21", "13:22-24", "13:25-27", "13:28-30", "13:31-33", "13:34-37", "13:35-37"},
+		{"13:38-40", "13:41-43", "13:44-46", "13:47-49", "13:50-52", "13:53-55", "13:56-59", "13:56-59"},
+	}},
+	"Metzora": {"Leviticus", [3][8]string{
+		{"14:1-5", "14:6-9", "14:10-12", "14:13-16", "14:17-20", "14:21-25", "14:26-32", "14:30-32"},
+		{"14:33-35", "14:36-38", "14:39-41", "14:42-44", "14:45-47", "14:48-50", "14:51-57", "14:54-57"},
+		{"15:1-4", "15:5-8", "15:9-12", "15:13-15", "15:16-18", "15:19-24", "15:25-33", "15:31-33"},
+	}},
+	"Achrei Mot": {"Leviticus", [3][8]string{
+		{"16:1-3", "16:4-6", "16:7-10", "16:11-14", "16:15-17", "16:18-20", "16:21-24", "16:22-24"},
+		{"16:25-28", "16:29-31", "16:32-34", "17:1-4", "17:5-7", "17:8-12", "17:13-16", "17:14-16"},
+		{"18:1-5", "18:6-11", "18:12-15", "18:16-18", "18:19-21", "18:22-25", "18:26-30", "18:28-30"},
+	}},
+	"Kedoshim": {"Leviticus", [3][8]string{
+		{"19:1-3", "19:4-6", "19:7-9", "19:10-12", "19:13-15", "19:16-18", "19:19-21", "19:19-21"},
+		{"19:22-24", "19:25-27", "19:28-30", "19:31-33", "19:34-37", "20:1-3", "20:4-6", "20:4-6"},
+		{"20:7-9", "20:10-12", "20:13-15", "20:16-18", "20:19-21", "20:22-24", "20:25-27", "20:25-27"},
+	}},
+	"Emor": {"Leviticus", [3][8]string{
+		{"21:1-6", "21:7-12", "21:13-15", "21:16-24", "22:1-6", "22:7-9", "22:10-16", "22:14-16"},
+		{"22:17-25", "22:26-33", "23:1-3", "23:4-8", "23:9-14", "23:15-18", "23:19-22", "23:20-22"},
+		{"23:23-25", "23:26-32", "23:33-38", "23:39-44", "24:1-4", "24:5-9", "24:10-23", "24:21-23"},
+	}},
+	"Behar":            {"Leviticus", [3][8]string{beharAnnual, beharAnnual, beharAnnual}},
+	"Bechukotai":       {"Leviticus", [3][8]string{bechukotaiAnnual, bechukotaiAnnual, bechukotaiAnnual}},
+	"Behar-Bechukotai": {"Leviticus", [3][8]string{beharBechukotaiAnnual, beharBechukotaiAnnual, beharBechukotaiAnnual}},
+	"Bamidbar": {"Numbers", [3][8]string{
+		{"1:1-4", "1:5-16", "1:17-19", "1:20-31", "1:32-43", "1:44-47", "1:48-54", "1:52-54"},
+		{"2:1-9", "2:10-16", "2:17-24", "2:25-31", "2:32-34", "3:1-4", "3:5-13", "3:11-13"},
+		{"3:14-20", "3:21-26", "3:27-32", "3:33-39", "3:40-51", "4:1-10", "4:11-20", "4:17-20"},
+	}},
+	"Nasso": {"Numbers", [3][8]string{
+		{"4:21-24", "4:25-28", "4:29-33", "4:34-37", "4:38-45", "4:46-49", "5:1-10", "5:8-10"},
+		{"5:11-15", "5:16-21", "5:22-26", "5:27-31", "6:1-8", "6:9-21", "6:22-27", "6:22-27"},
+		{"7:1-11", "7:12-23", "7:24-35", "7:36-47", "7:48-59", "7:60-71", "7:72-89", "7:87-89"},
+	}},
+	"Beha'alotcha": {"Numbers", [3][8]string{
+		{"8:1-4", "8:5-9", "8:10-14", "8:15-22", "8:23-26", "9:1-8", "9:9-14", "9:12-14"},
+		{"9:15-18", "9:19-23", "10:1-7", "10:8-10", "10:11-20", "10:21-28", "10:29-34", "10:32-34"},
+		{"10:35-11:9", "11:10-15", "11:16-22", "11:23-29", "11:30-35", "12:1-5", "12:6-16", "12:14-16"},
+	}},
+	"Sh'lach": {"Numbers", [3][8]string{
+		{"13:1-3", "13:4-16", "13:17-20", "13:21-24", "13:25-29", "13:30-33", "14:1-7", "14:5-7"},
+		{"14:8-10", "14:11-19", "14:20-25", "14:26-35", "14:36-39", "14:40-45", "15:1-7", "15:4-7"},
+		{"15:8-10", "15:11-16", "15:17-21", "15:22-26", "15:27-31", "15:32-36", "15:37-41", "15:37-41"},
+	}},
+	"Korach": {"Numbers", [3][8]string{
+		{"16:1-3", "16:4-7", "16:8-13", "16:14-19", "16:20-24", "16:25-30", "16:31-35", "16:33-35"},
+		{"17:1-5", "17:6-8", "17:9-11", "17:12-15", "17:16-20", "17:21-24", "17:25-28", "17:26-28"},
+		{"18:1-4", "18:5-7", "18:8-13", "18:14-20", "18:21-24", "18:25-29", "18:30-32", "18:30-32"},
+	}},
+	"Chukat": {"Numbers", [3][8]string{
+		{"19:1-6", "19:7-9", "19:10-13", "19:14-16", "19:17-22", "20:1-6", "20:7-13", "20:11-13"},
+		{"20:14-16", "20:17-21", "20:22-26", "20:27-29", "21:1-3", "21:4-6", "21:7-9", "21:7-9"},
+		{"21:10-12", "21:13-16", "21:17-20", "21:21-24", "21:25-28", "21:29-32", "21:33-22:1", "21:34-22:1"},
+	}},
+	"Balak": {"Numbers", [3][8]string{
+		{"22:2-4", "22:5-7", "22:8-12", "22:13-20", "22:21-27", "22:28-30", "22:31-38", "22:36-38"},
+		{"22:39-23:3", "23:4-6", "23:7-9", "23:10-12", "23:13-17", "23:18-20", "23:21-26", "23:24-26"},
+		{"23:27-30", "24:1-9", "24:10-13", "24:14-19", "24:20-25", "25:1-3", "25:4-9", "25:7-9"},
+	}},
+	"Pinchas": {"Numbers", [3][8]string{
+		{"25:10-12", "25:13-15", "25:16-19", "26:1-4", "26:5-18", "26:19-34", "26:35-51", "26:48-51"},
+		{"26:52-56", "26:57-65", "27:1-5", "27:6-11", "27:12-23", "28:1-8", "28:9-15", "28:11-15"},
+		{"28:16-25", "28:26-31", "29:1-6", "29:7-11", "29:12-16", "29:17-31", "29:32-30:1", "29:35-30:1"},
+	}},
+	"Matot": {"Numbers", [3][8]string{
+		{"30:2-5", "30:6-9", "30:10-13", "30:14-17", "31:1-4", "31:5-8", "31:9-12", "31:10-12"},
+		{"31:13-18", "31:19-24", "31:25-30", "31:31-35", "31:36-41", "31:42-47", "31:48-54", "31:52-54"},
+		{"32:1-5", "32:6-15", "32:16-19", "32:20-24", "32:25-32", "32:33-38", "32:39-42", "32:39-42"},
+	}},
+	"Masei": {"Numbers", [3][8]string{
+		{"33:1-4", "33:5-10", "33:11-18", "33:19-28", "33:29-36", "33:37-40", "33:41-49", "33:47-49"},
+		{"33:50-56", "34:1-9", "34:10-15", "34:16-21", "34:22-25", "34:26-29", "35:1-8", "35:6-8"},
+		{"35:9-12", "35:13-15", "35:16-21", "35:22-28", "35:29-34", "36:1-9", "36:10-13", "36:11-13"},
+	}},
+	"Devarim": {"Deuteronomy", [3][8]string{
+		{"1:1-3", "1:4-6", "1:7-9", "1:10-12", "1:13-15", "1:16-18", "1:19-21", "1:19-21"},
+		{"1:22-25", "1:26-28", "1:29-31", "1:32-34", "1:35-38", "1:39-41", "1:42-2:1", "1:45-2:1"},
+		{"2:2-8", "2:9-16", "2:17-23", "2:24-30", "2:31-37", "3:1-11", "3:12-22", "3:20-22"},
+	}},
+	"Vaetchanan": {"Deuteronomy", [3][8]string{
+		{"3:23-25", "3:26-4:4", "4:5-8", "4:9-14", "4:15-24", "4:25-29", "4:30-40", "4:37-40"},
+		{"4:41-43", "4:44-49", "5:1-5", "5:6-18", "5:19-23", "5:24-27", "5:28-6:3", "6:1-3"},
+		{"6:4-9", "6:10-15", "6:16-19", "6:20-25", "7:1-5", "7:6-8", "7:9-11", "7:9-11"},
+	}},
+	"Eikev": {"Deuteronomy", [3][8]string{
+		{"7:12-16", "7:17-21", "7:22-26", "8:1-3", "8:4-10", "8:11-18", "8:19-9:3", "9:1-3"},
+		{"9:4-6", "9:7-10", "9:11-14", "9:15-19", "9:20-24", "9:25-29", "10:1-11", "10:8-11"},
+		{"10:12-15", "10:16-22", "11:1-7", "11:8-12", "11:13-17", "11:18-21", "11:22-25", "11:22-25"},
+	}},
+	"Re'eh": {"Deuteronomy", [3][8]string{
+		{"11:26-28", "11:29-32", "12:1-7", "12:8-12", "12:13-16", "12:17-19", "12:20-28", "12:26-28"},
+		{"12:29-31", "13:1-6", "13:7-12", "13:13-19", "14:1-8", "14:9-21", "14:22-29", "14:27-29"},
+		{"15:1-6", "15:7-11", "15:12-18", "15:19-23", "16:1-8", "16:9-12", "16:13-17", "16:13-17"},
+	}},
+	"Shoftim": {"Deuteronomy", [3][8]string{
+		{"16:18-22", "17:1-3", "17:4-7", "17:8-13", "17:14-17", "17:18-20", "18:1-5", "18:3-5"},
+		{"18:6-8", "18:9-13", "18:14-18", "18:19-22", "19:1-3", "19:4-10", "19:11-13", "19:11-13"},
+		{"19:14-17", "19:18-21", "20:1-4", "20:5-9", "20:10-14", "20:15-20", "21:1-9", "21:7-9"},
+	}},
+	"Ki Teitzei": {"Deuteronomy", [3][8]string{
+		{"21:10-14", "21:15-17", "21:18-21", "21:22-22:2", "22:3-5", "22:6-8", "22:9-12", "22:10-12"},
+		{"22:13-19", "22:20-22", "22:23-29", "23:1-9", "23:10-15", "23:16-19", "23:20-24", "23:22-24"},
+		{"23:25-24:4", "24:5-9", "24:10-13", "24:14-18", "24:19-22", "25:1-10", "25:11-19", "25:17-19"},
+	}},
+	"Ki Tavo": {"Deuteronomy", [3][8]string{
+		{"26:1-3", "26:4-6", "26:7-9", "26:10-12", "26:13-15", "26:16-19", "27:1-3", "27:1-3"},
+		{"27:4-6", "27:7-10", "27:11-14", "27:15-17", "27:18-20", "27:21-23", "27:24-26", "27:24-26"},
+		{"28:1-3", "28:4-6", "28:7-10", "28:11-14", "28:15-69", "29:1-3", "29:4-8", "29:6-8"},
+	}},
+	"Nitzavim":           {"Deuteronomy", [3][8]string{nitzavimAnnual, nitzavimAnnual, nitzavimAnnual}},
+	"Vayeilech":          {"Deuteronomy", [3][8]string{vayeilechAnnual, vayeilechAnnual, vayeilechAnnual}},
+	"Nitzavim-Vayeilech": {"Deuteronomy", [3][8]string{nitzavimVayeilechAnnual, nitzavimVayeilechAnnual, nitzavimVayeilechAnnual}},
+	"Ha'azinu":           {"Deuteronomy", [3][8]string{haazinuAnnual, haazinuAnnual, haazinuAnnual}},
+}
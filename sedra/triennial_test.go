@@ -0,0 +1,139 @@
+package sedra_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/sedra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriennialYear(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(1, sedra.TriennialYear(5744))
+	assert.Equal(3, sedra.TriennialYear(5782))
+	assert.Equal(1, sedra.TriennialYear(5783))
+	assert.Equal(2, sedra.TriennialYear(5784))
+	assert.Equal(3, sedra.TriennialYear(5743))
+}
+
+func TestLookupTriennial(t *testing.T) {
+	assert := assert.New(t)
+	sedraYear := sedra.New(5783, false)
+	r, err := sedraYear.LookupTriennial(hdate.FromGregorian(2022, time.October, 22))
+	assert.Nil(err)
+	assert.Equal([]string{"Bereshit"}, r.Parsha.Name)
+	assert.Equal(1, r.Year)
+	assert.Equal([]string{
+		"Genesis 1:1-5",
+		"Genesis 1:6-8",
+		"Genesis 1:9-13",
+		"Genesis 1:14-19",
+		"Genesis 1:20-23",
+		"Genesis 1:24-31",
+		"Genesis 2:1-3",
+	}, r.Aliyot)
+	assert.Equal("Genesis 2:1-3", r.Maftir)
+
+	// Vayeilech and Ha'azinu in Tishrei complete the previous year's cycle
+	r, err = sedraYear.LookupTriennial(hdate.FromGregorian(2022, time.October, 1))
+	assert.Nil(err)
+	assert.Equal([]string{"Vayeilech"}, r.Parsha.Name)
+	assert.Equal(3, r.Year)
+	r, err = sedraYear.LookupTriennial(hdate.FromGregorian(2022, time.October, 8))
+	assert.Nil(err)
+	assert.Equal([]string{"Ha'azinu"}, r.Parsha.Name)
+	assert.Equal(3, r.Year)
+
+	// doubled parsha reads the year's portion of both parshiyot
+	r, err = sedraYear.LookupTriennial(hdate.FromGregorian(2023, time.March, 18))
+	assert.Nil(err)
+	assert.Equal([]string{"Vayakhel", "Pekudei"}, r.Parsha.Name)
+	assert.Equal([]string{
+		"Exodus 35:1-10",
+		"Exodus 35:11-20",
+		"Exodus 35:21-29",
+		"Exodus 38:21-27",
+		"Exodus 38:28-39:4",
+		"Exodus 39:5-14",
+		"Exodus 39:15-21",
+	}, r.Aliyot)
+	assert.Equal("Exodus 39:19-21", r.Maftir)
+
+	// Shabbat Chol HaMoed Sukkot
+	_, err = sedraYear.LookupTriennial(hdate.FromGregorian(2022, time.October, 15))
+	assert.NotNil(err)
+}
+
+func TestTriennialAliyot(t *testing.T) {
+	assert := assert.New(t)
+	// every parsha and doubled parsha read in a range of years
+	for _, il := range []bool{false, true} {
+		for year := 5780; year <= 5800; year++ {
+			sedraYear := sedra.New(year, il)
+			start := hdate.New(year, hdate.Tishrei, 1).OnOrAfter(time.Saturday)
+			end := hdate.New(year+1, hdate.Tishrei, 1)
+			for hd := start; hd.Abs() < end.Abs(); hd = hdate.FromRD(hd.Abs() + 7) {
+				parsha := sedraYear.Lookup(hd)
+				if parsha.Chag {
+					continue
+				}
+				r, err := sedraYear.LookupTriennial(hd)
+				assert.Nil(err, hd.String())
+				assert.Equal(7, len(r.Aliyot))
+				assert.NotEqual("", r.Maftir)
+			}
+		}
+	}
+	_, _, err := sedra.TriennialAliyot("Bereshit", 4)
+	assert.NotNil(err)
+	_, _, err = sedra.TriennialAliyot("Vezot Haberakhah", 1)
+	assert.NotNil(err)
+	_, _, err = sedra.TriennialAliyot("Bereshit-Noach-Lech", 1)
+	assert.NotNil(err)
+	aliyot, maftir, err := sedra.TriennialAliyot("Bereshit", 3)
+	assert.Nil(err)
+	assert.Equal([]string{
+		"Genesis 5:1-5",
+		"Genesis 5:6-8",
+		"Genesis 5:9-14",
+		"Genesis 5:15-20",
+		"Genesis 5:21-24",
+		"Genesis 5:25-31",
+		"Genesis 5:32-6:8",
+	}, aliyot)
+	assert.Equal("Genesis 6:5-8", maftir)
+
+	// short parshiyot are read in full every year
+	aliyot, maftir, err = sedra.TriennialAliyot("Ha'azinu", 2)
+	assert.Nil(err)
+	assert.Equal("Deuteronomy 32:1-6", aliyot[0])
+	assert.Equal("Deuteronomy 32:44-52", aliyot[6])
+	assert.Equal("Deuteronomy 32:48-52", maftir)
+	aliyot, _, err = sedra.TriennialAliyot("Behar-Bechukotai", 3)
+	assert.Nil(err)
+	assert.Equal("Leviticus 25:1-18", aliyot[0])
+	assert.Equal("Leviticus 27:1-34", aliyot[6])
+}
+
+func ExampleSedra_LookupTriennial() {
+	sedraYear := sedra.New(5784, false)
+	r, _ := sedraYear.LookupTriennial(hdate.FromGregorian(2023, time.October, 14))
+	fmt.Println(r.Parsha, "year", r.Year)
+	for i, aliyah := range r.Aliyot {
+		fmt.Println(i+1, aliyah)
+	}
+	fmt.Println("maftir", r.Maftir)
+	// Output:
+	// Parashat Bereshit year 2
+	// 1 Genesis 2:4-9
+	// 2 Genesis 2:10-19
+	// 3 Genesis 2:20-25
+	// 4 Genesis 3:1-21
+	// 5 Genesis 3:22-24
+	// 6 Genesis 4:1-18
+	// 7 Genesis 4:19-26
+	// maftir Genesis 4:23-26
+}
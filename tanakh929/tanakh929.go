@@ -0,0 +1,220 @@
+// Hebcal's tanakh929 package calculates the 929 program, which
+// learns one chapter of Tanakh each day from Sunday through Thursday,
+// finishing all 929 chapters in about three and a half years.
+package tanakh929
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hebcal/greg"
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/dafyomi"
+)
+
+// The 24 books of Tanakh, in order, with Samuel, Kings,
+// Ezra-Nehemiah, Chronicles and the Twelve Prophets
+// divided as they are numbered.
+var tanakh = []dafyomi.Daf{
+	// Torah
+	{Name: "Genesis", Blatt: 50},
+	{Name: "Exodus", Blatt: 40},
+	{Name: "Leviticus", Blatt: 27},
+	{Name: "Numbers", Blatt: 36},
+	{Name: "Deuteronomy", Blatt: 34},
+	// Nevi'im
+	{Name: "Joshua", Blatt: 24},
+	{Name: "Judges", Blatt: 21},
+	{Name: "I Samuel", Blatt: 31},
+	{Name: "II Samuel", Blatt: 24},
+	{Name: "I Kings", Blatt: 22},
+	{Name: "II Kings", Blatt: 25},
+	{Name: "Isaiah", Blatt: 66},
+	{Name: "Jeremiah", Blatt: 52},
+	{Name: "Ezekiel", Blatt: 48},
+	{Name: "Hosea", Blatt: 14},
+	{Name: "Joel", Blatt: 4},
+	{Name: "Amos", Blatt: 9},
+	{Name: "Obadiah", Blatt: 1},
+	{Name: "Jonah", Blatt: 4},
+	{Name: "Micah", Blatt: 7},
+	{Name: "Nachum", Blatt: 3},
+	{Name: "Habakkuk", Blatt: 3},
+	{Name: "Zephaniah", Blatt: 3},
+	{Name: "Haggai", Blatt: 2},
+	{Name: "Zechariah", Blatt: 14},
+	{Name: "Malachi", Blatt: 3},
+	// Ketuvim
+	{Name: "Psalms", Blatt: 150},
+	{Name: "Proverbs", Blatt: 31},
+	{Name: "Job", Blatt: 42},
+	{Name: "Song of Songs", Blatt: 8},
+	{Name: "Ruth", Blatt: 4},
+	{Name: "Lamentations", Blatt: 5},
+	{Name: "Ecclesiastes", Blatt: 12},
+	{Name: "Esther", Blatt: 10},
+	{Name: "Daniel", Blatt: 12},
+	{Name: "Ezra", Blatt: 10},
+	{Name: "Nehemiah", Blatt: 13},
+	{Name: "I Chronicles", Blatt: 29},
+	{Name: "II Chronicles", Blatt: 36},
+}
+
+// The R.D. number of the start of each 929 cycle. Like the first
+// cycle, each cycle begins with Genesis 1 on the Sunday after the
+// previous cycle concludes.
+var cycleStarts = []int64{
+	greg.ToRD(2014, time.December, 21), // 29 Kislev 5775
+	greg.ToRD(2018, time.August, 12),   // 1 Elul 5778
+	greg.ToRD(2022, time.April, 10),    // 9 Nisan 5782
+}
+
+// Start929 is the R.D. number of the start of the first 929 cycle,
+// Sunday 21 December 2014 (29 Kislev 5775).
+var Start929 = cycleStarts[0]
+
+const numChapters = 929
+
+// Index929 is an index by learning day of the entire 929 cycle.
+type Index929 []dafyomi.Daf
+
+// MakeIndex initializes the index for 929.
+func MakeIndex() Index929 {
+	days := make(Index929, numChapters)
+	i := 0
+	for _, book := range tanakh {
+		for k := 1; k <= book.Blatt; k++ {
+			days[i] = dafyomi.Daf{Name: book.Name, Blatt: k}
+			i++
+		}
+	}
+	return days
+}
+
+// Yom Tov days in Israel, on which no chapter is learned
+var yomTov = []struct {
+	month hdate.HMonth
+	day   int
+}{
+	{hdate.Tishrei, 1},
+	{hdate.Tishrei, 2},
+	{hdate.Tishrei, 10},
+	{hdate.Tishrei, 15},
+	{hdate.Tishrei, 22},
+	{hdate.Nisan, 15},
+	{hdate.Nisan, 21},
+	{hdate.Sivan, 6},
+}
+
+func isLearningDay(abs int64) bool {
+	hd := hdate.FromRD(abs)
+	if hd.Weekday() > time.Thursday {
+		return false
+	}
+	for _, yt := range yomTov {
+		if hd.Month() == yt.month && hd.Day() == yt.day {
+			return false
+		}
+	}
+	return true
+}
+
+// learningDays returns the number of learning days from
+// start, which must be a Sunday, through the day before abs.
+func learningDays(start, abs int64) int64 {
+	n := abs - start
+	days := (n/7)*5 + minInt64(n%7, 5)
+	startYear := hdate.FromRD(start).Year()
+	endYear := hdate.FromRD(abs).Year()
+	for year := startYear; year <= endYear; year++ {
+		for _, yt := range yomTov {
+			d := hdate.ToRD(year, yt.month, yt.day)
+			if d >= start && d < abs && time.Weekday(d%7) <= time.Thursday {
+				days--
+			}
+		}
+	}
+	return days
+}
+
+// nextCycle returns the start of the cycle following the one that
+// began on start: the Sunday after its last learning day.
+func nextCycle(start int64) int64 {
+	// at least numChapters learning days are needed, five per week
+	abs := start + (numChapters/5)*7
+	for learningDays(start, abs+1) < numChapters {
+		abs++
+	}
+	return abs + 7 - abs%7
+}
+
+// findCycle returns the number of the cycle in progress on abs,
+// beginning with 1, and the R.D. number of its first day. Cycles
+// after those in cycleStarts are assumed to follow the same rule.
+func findCycle(abs int64) (int, int64) {
+	n := 1
+	for n < len(cycleStarts) && cycleStarts[n] <= abs {
+		n++
+	}
+	start := cycleStarts[n-1]
+	if n == len(cycleStarts) {
+		for next := nextCycle(start); next <= abs; next = nextCycle(start) {
+			start = next
+			n++
+		}
+	}
+	return n, start
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Index929.Lookup calculates the 929 chapter for given date.
+//
+// Returns an error if the date is before the 929 program began
+// (21 December 2014), or if no chapter is learned that day: Friday,
+// Shabbat, Yom Tov and the days between the end of one cycle and
+// the start of the next.
+func (idx Index929) Lookup(hd hdate.HDate) (dafyomi.Daf, error) {
+	abs := hd.Abs()
+	if abs < Start929 {
+		return dafyomi.Daf{}, errors.New("before 929 cycle began")
+	}
+	_, start := findCycle(abs)
+	dayNum := learningDays(start, abs)
+	if !isLearningDay(abs) || dayNum >= numChapters {
+		return dafyomi.Daf{}, errors.New("no 929 chapter on " + hd.String())
+	}
+	return idx[dayNum], nil
+}
+
+// Cycle returns the number of the 929 cycle in progress on hd,
+// beginning with 1, or 0 if hd is before the program began.
+func Cycle(hd hdate.HDate) int {
+	abs := hd.Abs()
+	if abs < Start929 {
+		return 0
+	}
+	n, _ := findCycle(abs)
+	return n
+}
@@ -0,0 +1,86 @@
+package tanakh929_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/dafyomi"
+	"github.com/MaxBGreenberg/hebcal-go/tanakh929"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeIndex(t *testing.T) {
+	idx := tanakh929.MakeIndex()
+	assert.Equal(t, 929, len(idx))
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 1}, idx[0])
+	assert.Equal(t, dafyomi.Daf{Name: "Joshua", Blatt: 1}, idx[187])
+	assert.Equal(t, dafyomi.Daf{Name: "II Chronicles", Blatt: 36}, idx[928])
+}
+
+func Test929(t *testing.T) {
+	idx := tanakh929.MakeIndex()
+	chap, err := idx.Lookup(hdate.FromGregorian(2014, time.December, 21))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 1}, chap)
+	chap, _ = idx.Lookup(hdate.FromGregorian(2014, time.December, 28))
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 6}, chap)
+	// skips the first and seventh days of Pesach
+	chap, _ = idx.Lookup(hdate.FromGregorian(2023, time.April, 5))
+	assert.Equal(t, dafyomi.Daf{Name: "I Samuel", Blatt: 21}, chap)
+	chap, _ = idx.Lookup(hdate.FromGregorian(2023, time.April, 9))
+	assert.Equal(t, dafyomi.Daf{Name: "I Samuel", Blatt: 22}, chap)
+}
+
+func Test929LaterCycles(t *testing.T) {
+	idx := tanakh929.MakeIndex()
+	chap, _ := idx.Lookup(hdate.FromGregorian(2018, time.August, 7))
+	assert.Equal(t, dafyomi.Daf{Name: "II Chronicles", Blatt: 36}, chap)
+	// the second cycle began on Sunday 12 August 2018
+	chap, err := idx.Lookup(hdate.FromGregorian(2018, time.August, 12))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 1}, chap)
+	chap, _ = idx.Lookup(hdate.FromGregorian(2018, time.August, 16))
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 5}, chap)
+	chap, _ = idx.Lookup(hdate.FromGregorian(2022, time.April, 3))
+	assert.Equal(t, dafyomi.Daf{Name: "II Chronicles", Blatt: 36}, chap)
+	// the third cycle began on Sunday 10 April 2022
+	chap, err = idx.Lookup(hdate.FromGregorian(2022, time.April, 10))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 1}, chap)
+	chap, _ = idx.Lookup(hdate.FromGregorian(2022, time.April, 14))
+	assert.Equal(t, dafyomi.Daf{Name: "Genesis", Blatt: 5}, chap)
+}
+
+func TestNo929(t *testing.T) {
+	idx := tanakh929.MakeIndex()
+	_, err := idx.Lookup(hdate.FromGregorian(2014, time.December, 20))
+	assert.Equal(t, errors.New("before 929 cycle began"), err)
+	_, err = idx.Lookup(hdate.FromGregorian(2023, time.April, 7))
+	assert.Equal(t, errors.New("no 929 chapter on 16 Nisan 5783"), err)
+	// Pesach I, a Thursday
+	_, err = idx.Lookup(hdate.FromGregorian(2023, time.April, 6))
+	assert.Error(t, err)
+	// between the first and second cycles
+	_, err = idx.Lookup(hdate.FromGregorian(2018, time.August, 8))
+	assert.Equal(t, errors.New("no 929 chapter on 27 Av 5778"), err)
+}
+
+func TestCycle(t *testing.T) {
+	assert.Equal(t, 0, tanakh929.Cycle(hdate.FromGregorian(2014, time.December, 1)))
+	assert.Equal(t, 1, tanakh929.Cycle(hdate.FromGregorian(2014, time.December, 21)))
+	assert.Equal(t, 1, tanakh929.Cycle(hdate.FromGregorian(2018, time.August, 8)))
+	assert.Equal(t, 2, tanakh929.Cycle(hdate.FromGregorian(2018, time.August, 12)))
+	assert.Equal(t, 2, tanakh929.Cycle(hdate.FromGregorian(2022, time.April, 3)))
+	assert.Equal(t, 3, tanakh929.Cycle(hdate.FromGregorian(2022, time.April, 10)))
+	assert.Equal(t, 3, tanakh929.Cycle(hdate.FromGregorian(2023, time.April, 5)))
+}
+
+func ExampleIndex929_Lookup() {
+	idx := tanakh929.MakeIndex()
+	chapter, _ := idx.Lookup(hdate.FromGregorian(2022, time.August, 1))
+	fmt.Println(chapter)
+	// Output: Exodus 31
+}
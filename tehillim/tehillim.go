@@ -0,0 +1,126 @@
+// Hebcal's tehillim package calculates the daily portion of
+// Tehillim (Psalms) in the monthly and weekly cycles.
+package tehillim
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"strconv"
+
+	"github.com/hebcal/hdate"
+)
+
+// Cycle is a schedule for completing the book of Psalms.
+type Cycle int
+
+const (
+	// Monthly completes Psalms in a Hebrew month, keyed by day of month
+	Monthly Cycle = iota
+	// Weekly completes Psalms in a week, from Sunday through Shabbat
+	Weekly
+)
+
+const numChapters = 150
+
+// Portion is the range of Psalms read on a given day.
+type Portion struct {
+	Begin int // first chapter
+	End   int // last chapter (inclusive)
+	// Psalm 119 is divided between two days of the monthly cycle.
+	// BeginVerse and EndVerse are its verses, or 0 if whole
+	// chapters are read.
+	BeginVerse int
+	EndVerse   int
+}
+
+// Returns a string representation such as "Psalms 1-9"
+// or "Psalms 119:1-96"
+func (p Portion) String() string {
+	s := "Psalms " + strconv.Itoa(p.Begin)
+	if p.BeginVerse != 0 {
+		return s + ":" + strconv.Itoa(p.BeginVerse) + "-" + strconv.Itoa(p.EndVerse)
+	}
+	if p.End != p.Begin {
+		s += "-" + strconv.Itoa(p.End)
+	}
+	return s
+}
+
+// First chapter read on each of the 30 days of the monthly cycle.
+// Psalm 119 is read over days 25 and 26.
+var monthlyBegin = []int{
+	1, 10, 18, 23, 29, 35, 39, 44, 49, 55,
+	60, 66, 69, 72, 77, 79, 83, 88, 90, 97,
+	104, 106, 108, 113, 119, 119, 120, 135, 140, 145,
+}
+
+// First chapter read on each day of the week, Sunday through Shabbat.
+var weeklyBegin = []int{1, 30, 51, 73, 90, 107, 120}
+
+// makePortions divides Psalms into consecutive portions
+// beginning with the given chapters.
+func makePortions(begin []int) []Portion {
+	portions := make([]Portion, len(begin))
+	for i, chapter := range begin {
+		end := numChapters
+		if i+1 < len(begin) {
+			end = begin[i+1] - 1
+		}
+		if end < chapter {
+			end = chapter
+		}
+		portions[i] = Portion{Begin: chapter, End: end}
+	}
+	return portions
+}
+
+// MonthlyIndex is an index by day of month of the monthly cycle.
+type MonthlyIndex []Portion
+
+// MakeMonthlyIndex initializes the index for the monthly cycle.
+func MakeMonthlyIndex() MonthlyIndex {
+	days := MonthlyIndex(makePortions(monthlyBegin))
+	days[24] = Portion{Begin: 119, End: 119, BeginVerse: 1, EndVerse: 96}
+	days[25] = Portion{Begin: 119, End: 119, BeginVerse: 97, EndVerse: 176}
+	return days
+}
+
+// MonthlyIndex.Lookup returns the monthly cycle portion for the given date.
+//
+// In a month of 29 days, the portions for days 29 and 30
+// are both read on the 29th.
+func (idx MonthlyIndex) Lookup(hd hdate.HDate) Portion {
+	day := hd.Day()
+	portion := idx[day-1]
+	if day == 29 && hdate.DaysInMonth(hd.Month(), hd.Year()) == 29 {
+		portion.End = idx[29].End
+	}
+	return portion
+}
+
+// WeeklyIndex is an index by day of week of the weekly cycle.
+type WeeklyIndex []Portion
+
+// MakeWeeklyIndex initializes the index for the weekly cycle.
+func MakeWeeklyIndex() WeeklyIndex {
+	return WeeklyIndex(makePortions(weeklyBegin))
+}
+
+// WeeklyIndex.Lookup returns the weekly cycle portion for the given date.
+func (idx WeeklyIndex) Lookup(hd hdate.HDate) Portion {
+	return idx[hd.Weekday()]
+}
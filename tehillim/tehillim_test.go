@@ -0,0 +1,58 @@
+package tehillim_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hebcal/hdate"
+	"github.com/MaxBGreenberg/hebcal-go/tehillim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthly(t *testing.T) {
+	idx := tehillim.MakeMonthlyIndex()
+	assert.Equal(t, 30, len(idx))
+	assert.Equal(t, tehillim.Portion{Begin: 1, End: 9}, idx.Lookup(hdate.New(5783, hdate.Tishrei, 1)))
+	assert.Equal(t, tehillim.Portion{Begin: 119, End: 119, BeginVerse: 1, EndVerse: 96},
+		idx.Lookup(hdate.New(5783, hdate.Tishrei, 25)))
+	assert.Equal(t, "Psalms 119:97-176", idx.Lookup(hdate.New(5783, hdate.Tishrei, 26)).String())
+	assert.Equal(t, "Psalms 120-134", idx.Lookup(hdate.New(5783, hdate.Tishrei, 27)).String())
+	assert.Equal(t, "Psalms 140-144", idx.Lookup(hdate.New(5783, hdate.Tishrei, 29)).String())
+	assert.Equal(t, "Psalms 145-150", idx.Lookup(hdate.New(5783, hdate.Tishrei, 30)).String())
+}
+
+func TestMonthlyShortMonth(t *testing.T) {
+	idx := tehillim.MakeMonthlyIndex()
+	// Elul always has 29 days
+	assert.Equal(t, "Psalms 140-150", idx.Lookup(hdate.New(5782, hdate.Elul, 29)).String())
+	// Cheshvan 5783 has 30 days, Cheshvan 5784 has 29
+	assert.Equal(t, "Psalms 140-144", idx.Lookup(hdate.New(5783, hdate.Cheshvan, 29)).String())
+	assert.Equal(t, "Psalms 140-150", idx.Lookup(hdate.New(5784, hdate.Cheshvan, 29)).String())
+}
+
+func TestWeekly(t *testing.T) {
+	idx := tehillim.MakeWeeklyIndex()
+	expected := []string{
+		"Psalms 1-29",
+		"Psalms 30-50",
+		"Psalms 51-72",
+		"Psalms 73-89",
+		"Psalms 90-106",
+		"Psalms 107-119",
+		"Psalms 120-150",
+	}
+	// Sunday, 4 September 2022
+	start := hdate.FromGregorian(2022, time.September, 4)
+	for i, s := range expected {
+		hd := hdate.FromRD(start.Abs() + int64(i))
+		assert.Equal(t, s, idx.Lookup(hd).String())
+	}
+}
+
+func ExampleMonthlyIndex_Lookup() {
+	idx := tehillim.MakeMonthlyIndex()
+	portion := idx.Lookup(hdate.FromGregorian(2022, time.August, 1))
+	fmt.Println(portion)
+	// Output: Psalms 23-28
+}
@@ -40,5 +40,8 @@ Hebcal's zmanim package calculates halachic times.
     in the night sky with the naked eye;
     50 minutes: when 3 small stars are observable in the night sky with the naked eye;
     72 minutes: when 3 small stars are observable in the night sky with the naked eye.
+
+Because the Hebrew day begins at nightfall, HebrewDate, IsAfterSunset and
+HebrewDayEnd convert an instant at a location into the halachic Hebrew date.
 */
 package zmanim
@@ -0,0 +1,90 @@
+package zmanim
+
+// Hebcal - A Jewish Calendar Generator
+// Copyright (c) 2022 Michael J. Radwin
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+import (
+	"time"
+
+	"github.com/hebcal/hdate"
+)
+
+// dayBoundary returns the time at which the Hebrew day that begins
+// on the evening of z's date starts: sunset if angle is 0, otherwise
+// when the sun is angle degrees below the horizon (e.g. Tzeit3SmallStars).
+//
+// If the sun does not set (or reach the angle) that day, the boundary
+// falls back to the following local midnight.
+func (z *Zmanim) dayBoundary(angle float64) time.Time {
+	var t time.Time
+	if angle == 0 {
+		t = z.Sunset()
+	} else {
+		t = z.timeAtAngle(angle, false)
+	}
+	if t == nilTime {
+		t = time.Date(z.Year, z.Month, z.Day+1, 0, 0, 0, 0, z.loc)
+	}
+	return t
+}
+
+// civilDay returns the Zmanim for the local calendar day containing t,
+// offset by the given number of days.
+func civilDay(t time.Time, location *Location, days int) Zmanim {
+	tz, err := time.LoadLocation(location.TimeZoneId)
+	if err != nil {
+		panic(err)
+	}
+	year, month, day := t.In(tz).Date()
+	return New(location, time.Date(year, month, day+days, 0, 0, 0, 0, time.UTC))
+}
+
+// IsAfterSunset reports whether t is at or after sunset on its local
+// calendar day at location, i.e. whether the next Hebrew day has begun.
+func IsAfterSunset(t time.Time, location *Location) bool {
+	z := civilDay(t, location, 0)
+	return !t.Before(z.dayBoundary(0))
+}
+
+// HebrewDate returns the halachic Hebrew date at instant t and location.
+// Unlike hdate.FromTime, which changes date at midnight, the Hebrew day
+// here begins at sunset when angle is 0, or at tzeit when the sun is
+// angle degrees below the horizon (e.g. Tzeit3MediumStars).
+//
+// This function panics if the location's timezone cannot be loaded.
+func HebrewDate(t time.Time, location *Location, angle float64) hdate.HDate {
+	z := civilDay(t, location, 0)
+	hd := hdate.FromGregorian(z.Year, z.Month, z.Day)
+	if !t.Before(z.dayBoundary(angle)) {
+		hd = hd.Next()
+	}
+	return hd
+}
+
+// HebrewDayEnd returns the instant at which the Hebrew day containing t
+// ends at location: the next sunset (angle 0) or tzeit (angle degrees
+// below the horizon) at or after t.
+//
+// This function panics if the location's timezone cannot be loaded.
+func HebrewDayEnd(t time.Time, location *Location, angle float64) time.Time {
+	z := civilDay(t, location, 0)
+	end := z.dayBoundary(angle)
+	if !t.Before(end) {
+		z = civilDay(t, location, 1)
+		end = z.dayBoundary(angle)
+	}
+	return end
+}
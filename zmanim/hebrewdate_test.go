@@ -0,0 +1,54 @@
+package zmanim_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MaxBGreenberg/hebcal-go/zmanim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHebrewDate(t *testing.T) {
+	location := zmanim.LookupCity("Chicago")
+	tz, _ := time.LoadLocation("America/Chicago")
+	z := zmanim.New(location, time.Date(2020, time.June, 5, 0, 0, 0, 0, time.UTC))
+	sunset := z.Sunset()
+
+	noon := time.Date(2020, time.June, 5, 12, 0, 0, 0, tz)
+	assert.Equal(t, "13 Sivan 5780", zmanim.HebrewDate(noon, location, 0).String())
+	assert.False(t, zmanim.IsAfterSunset(noon, location))
+	assert.Equal(t, sunset, zmanim.HebrewDayEnd(noon, location, 0))
+
+	justBefore := sunset.Add(-time.Second)
+	assert.Equal(t, "13 Sivan 5780", zmanim.HebrewDate(justBefore, location, 0).String())
+	assert.Equal(t, "14 Sivan 5780", zmanim.HebrewDate(sunset, location, 0).String())
+	assert.True(t, zmanim.IsAfterSunset(sunset, location))
+
+	// the day following sunset ends at the next day's sunset
+	z2 := zmanim.New(location, time.Date(2020, time.June, 6, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, z2.Sunset(), zmanim.HebrewDayEnd(sunset, location, 0))
+
+	// after sunset but before tzeit, still the previous day with a tzeit boundary
+	afterSunset := sunset.Add(10 * time.Minute)
+	assert.Equal(t, "13 Sivan 5780", zmanim.HebrewDate(afterSunset, location, zmanim.Tzeit3SmallStars).String())
+	tzeit := z.Tzeit(zmanim.Tzeit3SmallStars)
+	assert.Equal(t, tzeit, zmanim.HebrewDayEnd(afterSunset, location, zmanim.Tzeit3SmallStars))
+	assert.Equal(t, "14 Sivan 5780", zmanim.HebrewDate(tzeit, location, zmanim.Tzeit3SmallStars).String())
+
+	// an instant given in another time zone uses the location's local day
+	utc := time.Date(2020, time.June, 6, 3, 0, 0, 0, time.UTC) // 22:00 CDT on June 5
+	assert.Equal(t, "14 Sivan 5780", zmanim.HebrewDate(utc, location, 0).String())
+
+	// just after midnight local time is the same Hebrew day
+	midnight := time.Date(2020, time.June, 6, 0, 30, 0, 0, tz)
+	assert.Equal(t, "14 Sivan 5780", zmanim.HebrewDate(midnight, location, 0).String())
+	assert.False(t, zmanim.IsAfterSunset(midnight, location))
+}
+
+func TestHebrewDateNoSunset(t *testing.T) {
+	location := zmanim.NewLocation("Longyearbyen", "NO", 78.22, 15.65, "Arctic/Longyearbyen")
+	tz, _ := time.LoadLocation("Arctic/Longyearbyen")
+	noon := time.Date(2020, time.June, 21, 12, 0, 0, 0, tz)
+	assert.Equal(t, "29 Sivan 5780", zmanim.HebrewDate(noon, &location, 0).String())
+	assert.Equal(t, time.Date(2020, time.June, 22, 0, 0, 0, 0, tz), zmanim.HebrewDayEnd(noon, &location, 0))
+}